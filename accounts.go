@@ -0,0 +1,294 @@
+package jellyfin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var ErrAccountNotFound = errors.New("account not found")
+
+// Account is a user logged in to a server.
+type Account struct {
+	ServerURL  string `json:"ServerUrl"`
+	ServerName string `json:"ServerName"`
+	Session
+}
+
+// ID returns the key identifying the account, unique across servers.
+func (a Account) ID() string {
+	return a.ServerID + "/" + a.UserID
+}
+
+// AccountResult is the result of an operation fanned out to an account.
+type AccountResult[T any] struct {
+	Account Account
+	Value   T
+	Err     error
+}
+
+// Accounts manages logins to multiple servers and users. It creates and caches
+// a Client for each account, and tracks the active account. If a path is given,
+// the accounts, including their access tokens, are persisted to it.
+type Accounts struct {
+	clientName    string
+	clientVersion string
+	opts          []ClientOptionFunc
+	path          string
+
+	mu       sync.Mutex
+	accounts []Account
+	clients  map[string]*Client
+	active   string
+}
+
+type accountsFile struct {
+	Active   string    `json:"Active"`
+	Accounts []Account `json:"Accounts"`
+}
+
+// NewAccounts creates an account manager whose clients are created with the
+// given client name, version and options. If path is non-empty, previously
+// saved accounts are loaded from it, and changes are saved to it.
+func NewAccounts(path, clientName, clientVersion string, opts ...ClientOptionFunc) (*Accounts, error) {
+	a := &Accounts{
+		clientName:    clientName,
+		clientVersion: clientVersion,
+		opts:          opts,
+		path:          path,
+		clients:       make(map[string]*Client),
+	}
+	if path == "" {
+		return a, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return a, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load accounts: %v", err)
+	}
+	var f accountsFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("load accounts: %v", err)
+	}
+	a.accounts = f.Accounts
+	a.active = f.Active
+	return a, nil
+}
+
+// Login logs in to a server and adds the account, replacing any existing
+// account of the same user on the same server. The first account added
+// becomes the active account.
+func (a *Accounts) Login(serverURL, username, password string) (*Account, error) {
+	client, err := NewClient(serverURL, a.clientName, a.clientVersion, a.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("account login: %w", err)
+	}
+	if err := client.Login(username, password); err != nil {
+		return nil, fmt.Errorf("account login: %w", err)
+	}
+	account := Account{ServerURL: client.BaseURL().String(), Session: client.Session()}
+	if info, err := client.Ping(); err == nil {
+		account.ServerName = info.ServerName
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.putLocked(account)
+	a.clients[account.ID()] = client
+	return &account, a.saveLocked()
+}
+
+// Add adds a previously saved account, replacing any existing account
+// with the same ID. The first account added becomes the active account.
+// The account must have a server URL, server ID and user ID.
+func (a *Accounts) Add(account Account) error {
+	if account.ServerURL == "" || account.ServerID == "" || account.UserID == "" {
+		return errors.New("add account: server URL, server ID and user ID are required")
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.putLocked(account)
+	delete(a.clients, account.ID())
+	return a.saveLocked()
+}
+
+// Remove removes an account. If it was the active account, there is no active account.
+func (a *Accounts) Remove(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	i := a.indexLocked(id)
+	if i < 0 {
+		return ErrAccountNotFound
+	}
+	a.accounts = append(a.accounts[:i], a.accounts[i+1:]...)
+	delete(a.clients, id)
+	if a.active == id {
+		a.active = ""
+	}
+	return a.saveLocked()
+}
+
+// List returns all accounts in the order they were added.
+func (a *Accounts) List() []Account {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]Account{}, a.accounts...)
+}
+
+// Client returns the client for an account, creating it on first use.
+func (a *Accounts) Client(id string) (*Client, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.clientLocked(id)
+}
+
+// SetActive sets the active account.
+func (a *Accounts) SetActive(id string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.indexLocked(id) < 0 {
+		return ErrAccountNotFound
+	}
+	a.active = id
+	return a.saveLocked()
+}
+
+// Active returns the active account and its client.
+func (a *Accounts) Active() (*Account, *Client, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	i := a.indexLocked(a.active)
+	if i < 0 {
+		return nil, nil, ErrAccountNotFound
+	}
+	client, err := a.clientLocked(a.active)
+	if err != nil {
+		return nil, nil, err
+	}
+	account := a.accounts[i]
+	return &account, client, nil
+}
+
+// Search runs SearchAll on each server concurrently, and returns the results
+// tagged with the account they came from.
+func (a *Accounts) Search(ctx context.Context, query string, opts SearchOptions) []AccountResult[*SearchResult] {
+	return FanOut(ctx, a, func(ctx context.Context, c *Client) (*SearchResult, error) {
+		return c.SearchAll(ctx, query, opts)
+	})
+}
+
+// FanOut runs fn concurrently once for each server, with the client of the
+// active account if it is on that server, or else the first account added
+// for the server. The results are in the order of the accounts.
+func FanOut[T any](ctx context.Context, a *Accounts, fn func(context.Context, *Client) (T, error)) []AccountResult[T] {
+	a.mu.Lock()
+	var accounts []Account
+	var clients []*Client
+	var errs []error
+	perServer := make(map[string]int)
+	for _, account := range a.accounts {
+		i, ok := perServer[account.ServerID]
+		if ok && account.ID() != a.active {
+			continue
+		}
+		client, err := a.clientLocked(account.ID())
+		if ok {
+			accounts[i], clients[i], errs[i] = account, client, err
+			continue
+		}
+		perServer[account.ServerID] = len(accounts)
+		accounts = append(accounts, account)
+		clients = append(clients, client)
+		errs = append(errs, err)
+	}
+	a.mu.Unlock()
+
+	results := make([]AccountResult[T], len(accounts))
+	var wg sync.WaitGroup
+	for i := range accounts {
+		results[i].Account = accounts[i]
+		if errs[i] != nil {
+			results[i].Err = errs[i]
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i].Value, results[i].Err = fn(ctx, clients[i])
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+func (a *Accounts) putLocked(account Account) {
+	if i := a.indexLocked(account.ID()); i >= 0 {
+		a.accounts[i] = account
+	} else {
+		a.accounts = append(a.accounts, account)
+	}
+	if a.active == "" {
+		a.active = account.ID()
+	}
+}
+
+func (a *Accounts) indexLocked(id string) int {
+	for i, account := range a.accounts {
+		if account.ID() == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (a *Accounts) clientLocked(id string) (*Client, error) {
+	if client, ok := a.clients[id]; ok {
+		return client, nil
+	}
+	i := a.indexLocked(id)
+	if i < 0 {
+		return nil, ErrAccountNotFound
+	}
+	account := a.accounts[i]
+	opts := append(append([]ClientOptionFunc{}, a.opts...), WithSession(account.Session))
+	client, err := NewClient(account.ServerURL, a.clientName, a.clientVersion, opts...)
+	if err != nil {
+		return nil, err
+	}
+	a.clients[id] = client
+	return client, nil
+}
+
+// saveLocked atomically writes the accounts file, readable only by the owner
+// since it contains access tokens.
+func (a *Accounts) saveLocked() error {
+	if a.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(accountsFile{Active: a.active, Accounts: a.accounts}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("save accounts: %v", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(a.path), filepath.Base(a.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("save accounts: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("save accounts: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("save accounts: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), a.path); err != nil {
+		return fmt.Errorf("save accounts: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package jellyfin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeServer answers logins and returns one song named after the server for searches.
+func fakeServer(serverID string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/Users/authenticatebyname":
+			var body map[string]string
+			json.NewDecoder(r.Body).Decode(&body)
+			json.NewEncoder(w).Encode(loginResponse{
+				User:     userResponse{UserId: "user-" + body["Username"]},
+				Token:    "token-" + body["Username"],
+				ServerId: serverID,
+			})
+		case r.URL.Path == "/System/Info/Public":
+			json.NewEncoder(w).Encode(PingResponse{Id: serverID, ServerName: serverID, ProductName: "Jellyfin Server"})
+		case strings.HasSuffix(r.URL.Path, "/Items"):
+			if !strings.Contains(r.Header.Get("Authorization"), "Token=") {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var items []map[string]string
+			if r.URL.Query().Get("IncludeItemTypes") == string(mediaTypeAudio) {
+				user := strings.TrimPrefix(strings.Split(r.URL.Path, "/")[2], "user-")
+				items = append(items, map[string]string{"Id": "song", "Name": serverID + " " + user})
+			}
+			json.NewEncoder(w).Encode(map[string]any{"Items": items, "TotalRecordCount": len(items)})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestAccounts(t *testing.T) {
+	home := fakeServer("home")
+	defer home.Close()
+	friend := fakeServer("friend")
+	defer friend.Close()
+
+	path := filepath.Join(t.TempDir(), "accounts.json")
+	accounts, err := NewAccounts(path, "test", "1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice, err := accounts.Login(home.URL, "alice", "pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := accounts.Login(home.URL, "bob", "pw"); err != nil {
+		t.Fatal(err)
+	}
+	carol, err := accounts.Login(friend.URL, "carol", "pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alice.ServerName != "home" || alice.Token != "token-alice" || alice.DeviceID == "" {
+		t.Errorf("got account %+v", alice)
+	}
+
+	// the first account is active until another is chosen
+	if active, _, err := accounts.Active(); err != nil || active.ID() != alice.ID() {
+		t.Errorf("got active account %v (err %v), want %s", active, err, alice.ID())
+	}
+	if err := accounts.SetActive("home/user-bob"); err != nil {
+		t.Fatal(err)
+	}
+
+	// accounts and tokens are restored from the saved file
+	reloaded, err := NewAccounts(path, "test", "1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(reloaded.List()); got != 3 {
+		t.Fatalf("got %d accounts after reload, want 3", got)
+	}
+	client, err := reloaded.Client(carol.ID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := client.Session(); got != carol.Session {
+		t.Errorf("got session %+v, want %+v", got, carol.Session)
+	}
+
+	// searches go to each server once, as the active account where possible
+	results := reloaded.Search(context.Background(), "x", SearchOptions{Types: []ItemType{TypeSong}})
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want one per server", len(results))
+	}
+	for i, want := range []string{"home bob", "friend carol"} {
+		r := results[i]
+		if r.Err != nil {
+			t.Errorf("%s: %v", r.Account.ID(), r.Err)
+			continue
+		}
+		if len(r.Value.Songs) != 1 || r.Value.Songs[0].Name != want {
+			t.Errorf("got songs %+v from %s, want %q", r.Value.Songs, r.Account.ID(), want)
+		}
+	}
+
+	if err := reloaded.Remove("home/user-bob"); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := reloaded.Active(); err != ErrAccountNotFound {
+		t.Errorf("got error %v after removing the active account, want ErrAccountNotFound", err)
+	}
+}
+
+func TestAccounts_Errors(t *testing.T) {
+	accounts, err := NewAccounts("", "test", "1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// login errors keep their cause
+	_, err = accounts.Login("http://127.0.0.1:1", "alice", "pw")
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		t.Errorf("got login error %v, want it to wrap a *url.Error", err)
+	}
+
+	for _, account := range []Account{
+		{ServerURL: "http://jellyfin", Session: Session{UserID: "user"}},
+		{ServerURL: "http://jellyfin", Session: Session{ServerID: "server"}},
+		{Session: Session{ServerID: "server", UserID: "user"}},
+	} {
+		if err := accounts.Add(account); err == nil {
+			t.Errorf("expected error adding incomplete account %+v", account)
+		}
+	}
+	if got := len(accounts.List()); got != 0 {
+		t.Errorf("got %d accounts, want incomplete accounts rejected", got)
+	}
+}
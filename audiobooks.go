@@ -0,0 +1,118 @@
+package jellyfin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+var audioBookIncludeFields = []string{"Genres", "DateCreated", "UserData", "Overview", "Chapters", "MediaSources"}
+
+var audioBookFields = fieldSet{
+	list:     []string{"Genres", "DateCreated"},
+	standard: audioBookIncludeFields,
+	detail:   append(append([]string{}, audioBookIncludeFields...), "ProviderIds", "People"),
+}
+
+// GetAudioBooks returns audiobooks with given sort, filter, and paging options.
+// - Can be used to get an author's books with the ArtistID filter set to the author ID.
+func (c *Client) GetAudioBooks(opts QueryOpts) ([]*AudioBook, error) {
+	params := c.defaultParams()
+	params.enableRecursive()
+	params.setPaging(opts.Paging)
+	params.setSorting(opts.Sort)
+	if err := params.setFilter(mediaTypeAudioBook, opts.Filter); err != nil {
+		return nil, err
+	}
+	params.setIncludeTypes(mediaTypeAudioBook)
+	params.setResponse(opts.Response, audioBookFields)
+	resp, err := c.get(fmt.Sprintf("/Users/%s/Items", c.userID), params)
+	if err != nil {
+		return nil, fmt.Errorf("get audiobooks: %v", err)
+	}
+	defer resp.Close()
+
+	return c.parseAudioBooks(resp)
+}
+
+func (c *Client) GetAudioBook(id string) (*AudioBook, error) {
+	book := &AudioBook{}
+	if err := c.getItemByID(id, book, audioBookIncludeFields...); err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+// GetAudioBookAuthors returns the authors of audiobooks in the library.
+// An author's books can be retrieved with GetAudioBooks and the ArtistID filter.
+func (c *Client) GetAudioBookAuthors(paging Paging) ([]*Artist, error) {
+	params := c.defaultParams()
+	params.enableRecursive()
+	params.setPaging(paging)
+	params.setSorting(Sort{Field: SortByName, Mode: SortAsc})
+	params.setIncludeTypes(mediaTypeAudioBook)
+	params.setIncludeFields(artistIncludeFields...)
+	resp, err := c.get("/Artists", params)
+	if err != nil {
+		return nil, fmt.Errorf("get audiobook authors: %v", err)
+	}
+	defer resp.Close()
+	return c.parseArtists(resp)
+}
+
+// GetResumeAudioBooks returns partially listened audiobooks, most recently listened first.
+func (c *Client) GetResumeAudioBooks(paging Paging) ([]*AudioBook, error) {
+	resp, err := c.getResumeItems(mediaTypeAudioBook, audioBookIncludeFields, paging)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	return c.parseAudioBooks(resp)
+}
+
+// SaveAudioBookPosition reports the listening position of an audiobook,
+// so that it is saved on the server and synced across devices.
+func (c *Client) SaveAudioBookPosition(id string, position time.Duration) error {
+	return c.UpdatePlayStatus(id, TimeUpdate, durationToTicks(position))
+}
+
+// ResumePosition returns the saved listening position of the book.
+func (b *AudioBook) ResumePosition() time.Duration {
+	return ticksToDuration(b.UserData.PlaybackPositionTicks)
+}
+
+// Duration returns the total length of the book.
+func (b *AudioBook) Duration() time.Duration {
+	return ticksToDuration(b.RunTimeTicks)
+}
+
+// ChapterAt returns the index of the chapter containing the position,
+// or -1 if the book has no chapters.
+func (b *AudioBook) ChapterAt(position time.Duration) int {
+	idx := -1
+	for i, ch := range b.Chapters {
+		if ch.Start() > position {
+			break
+		}
+		idx = i
+	}
+	if idx < 0 && len(b.Chapters) > 0 {
+		return 0
+	}
+	return idx
+}
+
+// Start returns the position at which the chapter starts.
+func (ch Chapter) Start() time.Duration {
+	return ticksToDuration(ch.StartPositionTicks)
+}
+
+func (c *Client) parseAudioBooks(resp io.Reader) ([]*AudioBook, error) {
+	dto := audioBooks{}
+	if err := json.NewDecoder(resp).Decode(&dto); err != nil {
+		return nil, fmt.Errorf("parse audiobooks: %v", err)
+	}
+	return dto.AudioBooks, nil
+}
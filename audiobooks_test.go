@@ -0,0 +1,76 @@
+package jellyfin
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetAudioBooks(t *testing.T) {
+	var req *http.Request
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		req = r
+		json.NewEncoder(w).Encode(map[string]any{"Items": []map[string]any{{
+			"Id":           "book",
+			"RunTimeTicks": durationToTicks(10 * time.Hour),
+			"UserData":     map[string]any{"PlaybackPositionTicks": durationToTicks(90 * time.Minute)},
+		}}})
+	})
+
+	books, err := c.GetAudioBooks(QueryOpts{Filter: Filter{ArtistID: "author"}, Paging: Paging{Limit: 10}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := req.URL.Query()
+	if req.URL.Path != "/Users/user/Items" || q.Get("IncludeItemTypes") != "AudioBook" || q.Get("ArtistIds") != "author" || q.Get("Recursive") != "true" {
+		t.Errorf("got request %s", req.URL)
+	}
+	if len(books) != 1 || books[0].ResumePosition() != 90*time.Minute || books[0].Duration() != 10*time.Hour {
+		t.Errorf("got books %+v", books)
+	}
+
+	if _, err := c.GetAudioBookAuthors(Paging{Limit: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if q := req.URL.Query(); req.URL.Path != "/Artists" || q.Get("IncludeItemTypes") != "AudioBook" || q.Get("Limit") != "5" {
+		t.Errorf("got authors request %s", req.URL)
+	}
+}
+
+func TestSaveAudioBookPosition(t *testing.T) {
+	var path string
+	var body playStatusBody
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := c.SaveAudioBookPosition("book", 1*time.Hour+30*time.Minute+250*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/Sessions/Playing/Progress" {
+		t.Errorf("got path %s, want progress report", path)
+	}
+	// ticks are 100ns units
+	if want := int64(54_002_500_000); body.PositionTicks != want || body.ItemId != "book" || body.EventName != string(TimeUpdate) {
+		t.Errorf("got body %+v, want %d ticks for book", body, want)
+	}
+}
+
+func TestAudioBook_ChapterAt(t *testing.T) {
+	book := &AudioBook{Chapters: []Chapter{
+		{StartPositionTicks: 0},
+		{StartPositionTicks: durationToTicks(10 * time.Minute)},
+		{StartPositionTicks: durationToTicks(25 * time.Minute)},
+	}}
+	for pos, want := range map[time.Duration]int{0: 0, 10 * time.Minute: 1, 20 * time.Minute: 1, 3 * time.Hour: 2} {
+		if got := book.ChapterAt(pos); got != want {
+			t.Errorf("ChapterAt(%v) = %d, want %d", pos, got, want)
+		}
+	}
+	if got := (&AudioBook{}).ChapterAt(time.Minute); got != -1 {
+		t.Errorf("ChapterAt without chapters = %d, want -1", got)
+	}
+}
@@ -0,0 +1,138 @@
+package jellyfin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	// maxIDsPerRequest keeps request URLs well under common server and proxy limits.
+	maxIDsPerRequest  = 100
+	maxParallelChunks = 4
+)
+
+// GetSongsByIDs returns the songs with the given IDs, in the same order.
+// Any IDs that were not found are returned as missing.
+func (c *Client) GetSongsByIDs(ctx context.Context, ids []string) ([]*Song, []string, error) {
+	return getItemsByIDs(ctx, c, ids, songIncludeFields, func(s *Song) string { return s.Id })
+}
+
+// GetAlbumsByIDs returns the albums with the given IDs, in the same order.
+// Any IDs that were not found are returned as missing.
+func (c *Client) GetAlbumsByIDs(ctx context.Context, ids []string) ([]*Album, []string, error) {
+	return getItemsByIDs(ctx, c, ids, albumIncludeFields, func(a *Album) string { return a.ID })
+}
+
+// GetArtistsByIDs returns the artists with the given IDs, in the same order.
+// Any IDs that were not found are returned as missing.
+func (c *Client) GetArtistsByIDs(ctx context.Context, ids []string) ([]*Artist, []string, error) {
+	return getItemsByIDs(ctx, c, ids, artistIncludeFields, func(a *Artist) string { return a.ID })
+}
+
+// GetItemsByIDs returns the items of any type with the given IDs, in the same order.
+// Any IDs that were not found are returned as missing.
+func (c *Client) GetItemsByIDs(ctx context.Context, ids []string) ([]*BaseItem, []string, error) {
+	return getItemsByIDs(ctx, c, ids, []string{"DateCreated", "ChildCount"}, func(i *BaseItem) string { return i.ID })
+}
+
+// getItemsByIDs fetches items by ID in parallel chunks and returns them in the
+// order of ids, along with the IDs that were not found.
+func getItemsByIDs[T any](ctx context.Context, c *Client, ids []string, includeFields []string, idOf func(T) string) ([]T, []string, error) {
+	params := c.defaultParams()
+	params.setIncludeFields(includeFields...)
+	return queryItemsByIDs(ctx, c, fmt.Sprintf("/Users/%s/Items", c.userID), params, ids, idOf)
+}
+
+// queryItemsByIDs runs the query on path for the given IDs in parallel chunks, so
+// that the request URLs stay short, and returns the items in the order of ids,
+// along with the IDs that were not found. The first failing chunk cancels the
+// remaining ones, and its error is returned.
+func queryItemsByIDs[T any](ctx context.Context, c *Client, path string, query params, ids []string, idOf func(T) string) ([]T, []string, error) {
+	unique := make([]string, 0, len(ids))
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	// chunks are keyed by their first ID, which is unique
+	chunks := make(map[string][]string)
+	var keys []string
+	for start := 0; start < len(unique); start += maxIDsPerRequest {
+		end := start + maxIDsPerRequest
+		if end > len(unique) {
+			end = len(unique)
+		}
+		keys = append(keys, unique[start])
+		chunks[unique[start]] = unique[start:end]
+	}
+
+	batchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var mu sync.Mutex
+	var firstErr error
+	found := make(map[string]T, len(unique))
+	err := runBatch(batchCtx, keys, maxParallelChunks, func(ctx context.Context, key string) error {
+		items, err := getItemsChunk[T](ctx, c, path, query, chunks[key])
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+				cancel()
+			}
+			return err
+		}
+		for _, item := range items {
+			found[idOf(item)] = item
+		}
+		return nil
+	})
+	if err != nil {
+		if firstErr == nil {
+			// no chunk failed, so ctx was done before they all started
+			firstErr = ctx.Err()
+		}
+		return nil, nil, fmt.Errorf("get items by IDs: %w", firstErr)
+	}
+
+	result := make([]T, 0, len(ids))
+	var missing []string
+	for _, id := range ids {
+		if item, ok := found[id]; ok {
+			result = append(result, item)
+		} else if seen[id] {
+			missing = append(missing, id)
+			// report each missing ID only once
+			seen[id] = false
+		}
+	}
+	return result, missing, nil
+}
+
+func getItemsChunk[T any](ctx context.Context, c *Client, path string, query params, ids []string) ([]T, error) {
+	params := params{}
+	for k, v := range query {
+		params[k] = v
+	}
+	params["Ids"] = strings.Join(ids, ",")
+	params["EnableTotalRecordCount"] = "false"
+	resp, err := c.getContext(ctx, path, params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	dto := struct {
+		Items []T `json:"Items"`
+	}{}
+	if err := json.NewDecoder(resp).Decode(&dto); err != nil {
+		return nil, fmt.Errorf("decode json: %v", err)
+	}
+	return dto.Items, nil
+}
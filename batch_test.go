@@ -0,0 +1,93 @@
+package jellyfin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetSongsByIDs(t *testing.T) {
+	var mu sync.Mutex
+	var chunkSizes []int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		ids := strings.Split(r.URL.Query().Get("Ids"), ",")
+		mu.Lock()
+		chunkSizes = append(chunkSizes, len(ids))
+		mu.Unlock()
+		// answer in reverse order, leaving out the missing songs
+		var items []map[string]string
+		for i := len(ids) - 1; i >= 0; i-- {
+			if !strings.HasPrefix(ids[i], "missing") {
+				items = append(items, map[string]string{"Id": ids[i]})
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"Items": items})
+	})
+
+	var ids []string
+	for i := 0; i < 2*maxIDsPerRequest+49; i++ {
+		ids = append(ids, fmt.Sprintf("song%d", i))
+	}
+	ids = append(ids, "missing1", "song0", "missing1")
+	songs, missing, err := c.GetSongsByIDs(context.Background(), ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(chunkSizes) != 3 {
+		t.Errorf("got %d requests, want 3", len(chunkSizes))
+	}
+	for _, n := range chunkSizes {
+		if n > maxIDsPerRequest {
+			t.Errorf("got request for %d IDs, want at most %d", n, maxIDsPerRequest)
+		}
+	}
+	want := append(append([]string{}, ids[:len(ids)-3]...), "song0")
+	if got := songIDs(songs); !reflect.DeepEqual(got, want) {
+		t.Errorf("got songs in wrong order: %v", got)
+	}
+	if !reflect.DeepEqual(missing, []string{"missing1"}) {
+		t.Errorf("got missing %v, want [missing1]", missing)
+	}
+}
+
+func TestGetSongsByIDs_Error(t *testing.T) {
+	var requests atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	ids := make([]string, 20*maxIDsPerRequest)
+	for i := range ids {
+		ids[i] = fmt.Sprint(i)
+	}
+
+	songs, _, err := c.GetSongsByIDs(context.Background(), ids)
+	if err == nil || songs != nil {
+		t.Fatalf("got %d songs and error %v, want an error", len(songs), err)
+	}
+	if responseStatus(err) != http.StatusInternalServerError {
+		t.Errorf("got error %v, want the server error", err)
+	}
+	// the first failure cancels the chunks not yet started
+	if n := requests.Load(); n > maxParallelChunks {
+		t.Errorf("got %d requests, want at most %d", n, maxParallelChunks)
+	}
+
+	requests.Store(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := c.GetSongsByIDs(ctx, ids); !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want context.Canceled", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("got %d requests with canceled context, want 0", n)
+	}
+}
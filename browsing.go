@@ -1,16 +1,50 @@
 package jellyfin
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net/http"
 )
 
 var (
 	songIncludeFields     = []string{"Genres", "DateCreated", "MediaSources", "UserData", "ParentId"}
 	albumIncludeFields    = []string{"Genres", "DateCreated", "ChildCount", "UserData", "ParentId"}
-	playlistIncludeFields = []string{"Genres", "DateCreated", "MediaSources", "ChildCount", "Parent", "Overview"}
+	playlistIncludeFields = []string{"Genres", "DateCreated", "MediaSources", "ChildCount", "Parent", "Overview", "CanDelete"}
 	artistIncludeFields   = []string{"ChildCount", "UserData"}
+
+	// songComposerFields also requests People, from which Song.Composers is
+	// populated. People is costly for the server, so it is not in list calls.
+	songComposerFields = append(append([]string{}, songIncludeFields...), "People")
+)
+
+var (
+	songFields = fieldSet{
+		list:     []string{"Genres", "DateCreated", "ParentId"},
+		standard: songIncludeFields,
+		detail:   append(append([]string{}, songComposerFields...), "Path", "ProviderIds", "MediaStreams"),
+	}
+	composerSongFields = fieldSet{
+		list:     songFields.list,
+		standard: songComposerFields,
+		detail:   songFields.detail,
+	}
+	albumFields = fieldSet{
+		list:     []string{"Genres", "DateCreated", "ChildCount"},
+		standard: albumIncludeFields,
+		detail:   append(append([]string{}, albumIncludeFields...), "Overview", "ProviderIds", "Studios", "Tags"),
+	}
+	artistFields = fieldSet{
+		list:     []string{"ChildCount"},
+		standard: artistIncludeFields,
+		detail:   append(append([]string{}, artistIncludeFields...), "Overview", "Genres", "ProviderIds"),
+	}
+	playlistFields = fieldSet{
+		list:     []string{"DateCreated", "ChildCount"},
+		standard: playlistIncludeFields,
+		detail:   append(append([]string{}, playlistIncludeFields...), "PremiereDate", "Tags", "ProviderIds"),
+	}
 )
 
 // GetUserViews returns top level collections that the
@@ -38,10 +72,23 @@ func (c *Client) GetAlbums(opts QueryOpts) ([]*Album, error) {
 	params.enableRecursive()
 	params.setPaging(opts.Paging)
 	params.setSorting(opts.Sort)
-	params.setFilter(mediaTypeAlbum, opts.Filter)
+	if err := params.setFilter(mediaTypeAlbum, opts.Filter); err != nil {
+		return nil, err
+	}
 	params.setIncludeTypes(mediaTypeAlbum)
-	params.setIncludeFields(albumIncludeFields...)
-	resp, err := c.get(fmt.Sprintf("/Users/%s/Items", c.userID), params)
+	params.setResponse(opts.Response, albumFields)
+	path := fmt.Sprintf("/Users/%s/Items", c.userID)
+
+	if opts.Sort.isSeededRandom() {
+		pageIDs, err := c.seededRandomPage(path, params, opts.Sort.Seed, opts.Paging)
+		if err != nil {
+			return nil, err
+		}
+		albums, _, err := queryItemsByIDs(context.Background(), c, path, params, pageIDs, func(a *Album) string { return a.ID })
+		return albums, err
+	}
+
+	resp, err := c.get(path, params)
 	if err != nil {
 		return nil, err
 	}
@@ -58,11 +105,13 @@ func (c *Client) GetAlbums(opts QueryOpts) ([]*Album, error) {
 func (c *Client) GetAlbumArtists(opts QueryOpts) ([]*Artist, error) {
 	params := c.defaultParams()
 	params.enableRecursive()
-	params.setFilter(mediaTypeArtist, opts.Filter)
+	if err := params.setFilter(mediaTypeArtist, opts.Filter); err != nil {
+		return nil, err
+	}
 	params.setPaging(opts.Paging)
 	params.setSorting(opts.Sort)
 	params.setIncludeTypes(mediaTypeAlbum)
-	params.setIncludeFields(artistIncludeFields...)
+	params.setResponse(opts.Response, artistFields)
 	resp, err := c.get("/Artists/AlbumArtists", params)
 	if err != nil {
 		return nil, err
@@ -71,6 +120,95 @@ func (c *Client) GetAlbumArtists(opts QueryOpts) ([]*Artist, error) {
 	return c.parseArtists(resp)
 }
 
+// GetArtists returns all artists, including artists that only appear as
+// contributing (track) artists, with given sort, filter, and paging options.
+func (c *Client) GetArtists(opts QueryOpts) ([]*Artist, error) {
+	params := c.defaultParams()
+	params.enableRecursive()
+	if err := params.setFilter(mediaTypeArtist, opts.Filter); err != nil {
+		return nil, err
+	}
+	params.setPaging(opts.Paging)
+	params.setSorting(opts.Sort)
+	params.setResponse(opts.Response, artistFields)
+	resp, err := c.get("/Artists", params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	return c.parseArtists(resp)
+}
+
+// GetAppearsOnAlbums returns albums the artist contributes to
+// without being one of the album artists.
+func (c *Client) GetAppearsOnAlbums(artistID string, opts QueryOpts) ([]*Album, error) {
+	params := c.defaultParams()
+	params.enableRecursive()
+	params.setPaging(opts.Paging)
+	params.setSorting(opts.Sort)
+	if err := params.setFilter(mediaTypeAlbum, opts.Filter); err != nil {
+		return nil, err
+	}
+	params.setIncludeTypes(mediaTypeAlbum)
+	params.setResponse(opts.Response, albumFields)
+	params["ContributingArtistIds"] = artistID
+	resp, err := c.get(fmt.Sprintf("/Users/%s/Items", c.userID), params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	albums := albums{}
+	err = json.NewDecoder(resp).Decode(&albums)
+	if err != nil {
+		return nil, fmt.Errorf("decode json: %v", err)
+	}
+	return albums.Albums, nil
+}
+
+// GetComposers returns people credited as composers in the library.
+func (c *Client) GetComposers(paging Paging) ([]NameID, error) {
+	params := c.defaultParams()
+	params.setPaging(paging)
+	params["PersonTypes"] = personTypeComposer
+	resp, err := c.get("/Persons", params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	body := struct {
+		Items []NameID
+	}{}
+	if err := json.NewDecoder(resp).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decode json: %v", err)
+	}
+	return body.Items, nil
+}
+
+// GetComposerSongs returns songs composed by the given person
+// with given sort, filter, and paging options.
+func (c *Client) GetComposerSongs(composerID string, opts QueryOpts) ([]*Song, error) {
+	params := c.defaultParams()
+	params.enableRecursive()
+	params.setIncludeTypes(mediaTypeAudio)
+	params.setPaging(opts.Paging)
+	params.setSorting(opts.Sort)
+	if err := params.setFilter(mediaTypeAudio, opts.Filter); err != nil {
+		return nil, err
+	}
+	params.setResponse(opts.Response, composerSongFields)
+	params["PersonIds"] = composerID
+	params["PersonTypes"] = personTypeComposer
+	resp, err := c.get(fmt.Sprintf("/Users/%s/Items", c.userID), params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	return c.parseSongs(resp)
+}
+
 func (c *Client) GetArtist(artistID string) (*Artist, error) {
 	artist := &Artist{}
 	includeFields := append(artistIncludeFields, "Overview")
@@ -93,7 +231,7 @@ func (c *Client) GetAlbum(albumID string) (*Album, error) {
 
 func (c *Client) GetSong(songID string) (*Song, error) {
 	song := &Song{}
-	err := c.getItemByID(songID, song, songIncludeFields...)
+	err := c.getItemByID(songID, song, songComposerFields...)
 	if err != nil {
 		return nil, err
 	}
@@ -120,7 +258,9 @@ func (c *Client) GetGenres(paging Paging, parentID string) ([]NameID, error) {
 	params.setSorting(Sort{Field: SortByName, Mode: SortAsc})
 	params.setPaging(paging)
 	if parentID != "" {
-		params.setFilter("Genre", Filter{ParentID: parentID})
+		if err := params.setFilter("Genre", Filter{ParentID: parentID}); err != nil {
+			return nil, err
+		}
 	}
 
 	resp, err := c.get("/MusicGenres", params)
@@ -151,11 +291,23 @@ func (c *Client) GetSongs(opts QueryOpts) ([]*Song, error) {
 	params.setIncludeTypes(mediaTypeAudio)
 	params.setPaging(opts.Paging)
 	params.setSorting(opts.Sort)
-	params.setFilter(mediaTypeAudio, opts.Filter)
+	if err := params.setFilter(mediaTypeAudio, opts.Filter); err != nil {
+		return nil, err
+	}
 	params.enableRecursive()
-	params.setIncludeFields(songIncludeFields...)
+	params.setResponse(opts.Response, songFields)
+	path := fmt.Sprintf("/Users/%s/Items", c.userID)
 
-	resp, err := c.get(fmt.Sprintf("/Users/%s/Items", c.userID), params)
+	if opts.Sort.isSeededRandom() {
+		pageIDs, err := c.seededRandomPage(path, params, opts.Sort.Seed, opts.Paging)
+		if err != nil {
+			return nil, err
+		}
+		songs, _, err := queryItemsByIDs(context.Background(), c, path, params, pageIDs, func(s *Song) string { return s.Id })
+		return songs, err
+	}
+
+	resp, err := c.get(path, params)
 	if err != nil {
 		return nil, err
 	}
@@ -165,7 +317,8 @@ func (c *Client) GetSongs(opts QueryOpts) ([]*Song, error) {
 }
 
 // GetPlaylists retrieves all playlists. Each playlists song count is known, but songs must be
-// retrieved separately
+// retrieved separately. The sharing information and ownership of the playlists is not
+// populated; use GetPlaylistsByOwnership or GetPlaylist for that.
 func (c *Client) GetPlaylists() ([]*Playlist, error) {
 	params := c.defaultParams()
 	params.setIncludeTypes(mediaTypePlaylist)
@@ -192,10 +345,11 @@ func (c *Client) GetPlaylists() ([]*Playlist, error) {
 			musicPlaylists = append(musicPlaylists, pl)
 		}
 	}
-
 	return musicPlaylists, nil
 }
 
+// GetPlaylist retrieves the playlist with its sharing information populated
+// on Jellyfin 10.9 or later, which takes one more request.
 func (c *Client) GetPlaylist(playlistID string) (*Playlist, error) {
 	playlist := &Playlist{}
 	includeFields := append(playlistIncludeFields, "PremiereDate", "Tags", "ProviderIds")
@@ -203,6 +357,16 @@ func (c *Client) GetPlaylist(playlistID string) (*Playlist, error) {
 	if err != nil {
 		return nil, err
 	}
+	// sharing information is only available from Jellyfin 10.9 on,
+	// so leave it unset if the server does not support it
+	dto, err := c.getPlaylistDto(playlistID)
+	if responseStatus(err) == http.StatusNotFound {
+		return playlist, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get playlist sharing: %v", err)
+	}
+	applyPlaylistDto(playlist, dto, c.userID)
 	return playlist, nil
 }
 
@@ -230,11 +394,15 @@ func (c *Client) GetInstantMix(id string, idType ItemType, limit int) ([]*Song,
 }
 
 func (c *Client) getItemByID(itemID string, dto interface{}, includeFields ...string) error {
+	return c.getItemByIDContext(context.Background(), itemID, dto, includeFields...)
+}
+
+func (c *Client) getItemByIDContext(ctx context.Context, itemID string, dto interface{}, includeFields ...string) error {
 	params := c.defaultParams()
 	if len(includeFields) > 0 {
 		params.setIncludeFields(includeFields...)
 	}
-	resp, err := c.get(fmt.Sprintf("/Users/%s/Items/%s", c.userID, itemID), params)
+	resp, err := c.getContext(ctx, fmt.Sprintf("/Users/%s/Items/%s", c.userID, itemID), params)
 	if err != nil {
 		return err
 	}
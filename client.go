@@ -15,6 +15,7 @@ import (
 	"os"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -34,7 +35,12 @@ type Client struct {
 	serverID string
 	username string
 	userID   string
+
+	// deviceMu guards deviceID, which is computed lazily by concurrent requests.
+	deviceMu sync.Mutex
 	deviceID string // needs to be unique for a user+device combo
+
+	randomOrders randomOrderCache
 }
 
 // NewClient creates a jellyfin Client using the url provided.
@@ -86,6 +92,49 @@ func WithHTTPClient(httpClient *http.Client) ClientOptionFunc {
 	}
 }
 
+// Device ID override. The device ID must be unique for each user and device.
+func WithDeviceID(deviceID string) ClientOptionFunc {
+	return func(c *Client) {
+		c.deviceID = deviceID
+	}
+}
+
+// Session restores a previous login, so that a Client can be used without
+// logging in again.
+func WithSession(session Session) ClientOptionFunc {
+	return func(c *Client) {
+		c.loggedIn = session.Token != ""
+		c.token = session.Token
+		c.serverID = session.ServerID
+		c.username = session.Username
+		c.userID = session.UserID
+		if session.DeviceID != "" {
+			c.deviceID = session.DeviceID
+		}
+	}
+}
+
+// Session is the state of a login, which can be persisted and later
+// restored with WithSession.
+type Session struct {
+	ServerID string `json:"ServerId"`
+	UserID   string `json:"UserId"`
+	Username string `json:"Username"`
+	Token    string `json:"Token"`
+	DeviceID string `json:"DeviceId"`
+}
+
+// Session returns the current login state of the Client.
+func (c *Client) Session() Session {
+	return Session{
+		ServerID: c.serverID,
+		UserID:   c.userID,
+		Username: c.username,
+		Token:    c.token,
+		DeviceID: c.ensureDeviceID(),
+	}
+}
+
 // BaseURL return a copy of the baseURL.
 func (c *Client) BaseURL() *url.URL {
 	u := *c.baseURL
@@ -150,7 +199,9 @@ func (c *Client) Login(username, password string) error {
 		c.serverID = dto.ServerId
 		c.username = username
 		c.userID = dto.User.UserId
+		c.deviceMu.Lock()
 		c.deviceID = "" // recalculate it next request, should be different per username
+		c.deviceMu.Unlock()
 	case http.StatusBadRequest:
 		reason, err := io.ReadAll(resp.Body)
 		if err != nil {
@@ -208,6 +259,8 @@ func (c *Client) authHeader() string {
 }
 
 func (c *Client) ensureDeviceID() string {
+	c.deviceMu.Lock()
+	defer c.deviceMu.Unlock()
 	if c.deviceID == "" {
 		mac, err := macaddress()
 		if err != nil {
@@ -2,11 +2,26 @@ package jellyfin
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"reflect"
 	"testing"
 )
 
+// newTestClient starts a server running handler and returns
+// a client logged in to it as the user "user".
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	session := Session{ServerID: "server", UserID: "user", Token: "token", DeviceID: "device"}
+	c, err := NewClient(srv.URL, "test", "1.0", WithSession(session))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
 func TestNewClient(t *testing.T) {
 	tests := []struct {
 		name          string
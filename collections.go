@@ -0,0 +1,121 @@
+package jellyfin
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+var collectionIncludeFields = []string{"Genres", "DateCreated", "ChildCount", "UserData", "Overview"}
+
+var collectionFields = fieldSet{
+	list:     []string{"DateCreated", "ChildCount"},
+	standard: collectionIncludeFields,
+	detail:   collectionIncludeFields,
+}
+
+// GetCollections returns collections (BoxSets) with given sort, filter, and paging options.
+func (c *Client) GetCollections(opts QueryOpts) ([]*Collection, error) {
+	params := c.defaultParams()
+	params.enableRecursive()
+	params.setPaging(opts.Paging)
+	params.setSorting(opts.Sort)
+	if err := params.setFilter(mediaTypeBoxSet, opts.Filter); err != nil {
+		return nil, err
+	}
+	params.setIncludeTypes(mediaTypeBoxSet)
+	params.setResponse(opts.Response, collectionFields)
+	resp, err := c.get(fmt.Sprintf("/Users/%s/Items", c.userID), params)
+	if err != nil {
+		return nil, fmt.Errorf("get collections: %v", err)
+	}
+	defer resp.Close()
+
+	dto := collections{}
+	if err := json.NewDecoder(resp).Decode(&dto); err != nil {
+		return nil, fmt.Errorf("decode json: %v", err)
+	}
+	return dto.Collections, nil
+}
+
+func (c *Client) GetCollection(collectionID string) (*Collection, error) {
+	collection := &Collection{}
+	if err := c.getItemByID(collectionID, collection, collectionIncludeFields...); err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+// GetCollectionItems returns the items in a collection, which may be
+// of any type (albums, artists, songs, ...).
+func (c *Client) GetCollectionItems(collectionID string, paging Paging) ([]*BaseItem, error) {
+	params := c.defaultParams()
+	params.setPaging(paging)
+	params.setSorting(Sort{Field: SortByName, Mode: SortAsc})
+	params.setIncludeFields("DateCreated", "ChildCount", "UserData")
+	params["ParentId"] = collectionID
+	resp, err := c.get(fmt.Sprintf("/Users/%s/Items", c.userID), params)
+	if err != nil {
+		return nil, fmt.Errorf("get collection items: %v", err)
+	}
+	defer resp.Close()
+
+	dto := items{}
+	if err := json.NewDecoder(resp).Decode(&dto); err != nil {
+		return nil, fmt.Errorf("decode json: %v", err)
+	}
+	return dto.Items, nil
+}
+
+// CreateCollection creates a new collection containing the given items and returns it.
+func (c *Client) CreateCollection(name string, itemIDs []string) (*Collection, error) {
+	params := c.defaultParams()
+	params["Name"] = name
+	if len(itemIDs) > 0 {
+		params["Ids"] = strings.Join(itemIDs, ",")
+	}
+	resp, err := c.post("/Collections", params, struct{}{})
+	if err != nil {
+		return nil, fmt.Errorf("create collection: %v", err)
+	}
+	defer resp.Close()
+
+	var created struct {
+		ID string `json:"Id"`
+	}
+	if err := json.NewDecoder(resp).Decode(&created); err != nil {
+		return nil, fmt.Errorf("create collection: decode response: %v", err)
+	}
+	return c.GetCollection(created.ID)
+}
+
+func (c *Client) AddToCollection(collectionID string, itemIDs []string) error {
+	params := c.defaultParams()
+	params["Ids"] = strings.Join(itemIDs, ",")
+	resp, err := c.post(fmt.Sprintf("/Collections/%s/Items", collectionID), params, struct{}{})
+	if err != nil {
+		return fmt.Errorf("add to collection: %v", err)
+	}
+	resp.Close()
+	return nil
+}
+
+func (c *Client) RemoveFromCollection(collectionID string, itemIDs []string) error {
+	params := c.defaultParams()
+	params["Ids"] = strings.Join(itemIDs, ",")
+	resp, err := c.delete(fmt.Sprintf("/Collections/%s/Items", collectionID), params)
+	if err != nil {
+		return fmt.Errorf("remove from collection: %v", err)
+	}
+	resp.Close()
+	return nil
+}
+
+func (c *Client) DeleteCollection(collectionID string) error {
+	resp, err := c.delete(fmt.Sprintf("/Items/%s", collectionID), c.defaultParams())
+	if err != nil {
+		return fmt.Errorf("delete collection: %v", err)
+	}
+	resp.Close()
+	return nil
+}
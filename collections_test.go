@@ -0,0 +1,67 @@
+package jellyfin
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestCollections(t *testing.T) {
+	var requests []*http.Request
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/Collections":
+			json.NewEncoder(w).Encode(map[string]string{"Id": "new"})
+		case r.URL.Path == "/Users/user/Items/new":
+			json.NewEncoder(w).Encode(map[string]any{"Id": "new", "Name": r.URL.Query().Get("Name")})
+		case r.URL.Path == "/Users/user/Items":
+			json.NewEncoder(w).Encode(map[string]any{"Items": []map[string]string{{"Id": "bs1"}}, "TotalRecordCount": 1})
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	collections, err := c.GetCollections(QueryOpts{Paging: Paging{StartIndex: 5, Limit: 10}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(collections) != 1 || collections[0].ID != "bs1" {
+		t.Errorf("got collections %+v", collections)
+	}
+	q := requests[0].URL.Query()
+	if q.Get("IncludeItemTypes") != "BoxSet" || q.Get("Recursive") != "true" || q.Get("StartIndex") != "5" || q.Get("Limit") != "10" {
+		t.Errorf("got query %v", q)
+	}
+
+	requests = nil
+	if _, err := c.CreateCollection("Favorites", []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if q := requests[0].URL.Query(); q.Get("Name") != "Favorites" || q.Get("Ids") != "a,b" {
+		t.Errorf("got create query %v", q)
+	}
+	if len(requests) != 2 || requests[1].URL.Path != "/Users/user/Items/new" {
+		t.Errorf("created collection not fetched")
+	}
+
+	requests = nil
+	if err := c.AddToCollection("bs1", []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.RemoveFromCollection("bs1", []string{"c"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteCollection("bs1"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"POST /Collections/bs1/Items?a,b", "DELETE /Collections/bs1/Items?c", "DELETE /Items/bs1?"}
+	if len(requests) != len(want) {
+		t.Fatalf("got %d requests, want %d", len(requests), len(want))
+	}
+	for i, r := range requests {
+		if got := r.Method + " " + r.URL.Path + "?" + r.URL.Query().Get("Ids"); got != want[i] {
+			t.Errorf("got request %s, want %s", got, want[i])
+		}
+	}
+}
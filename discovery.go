@@ -0,0 +1,93 @@
+package jellyfin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	discoveryAddress = "255.255.255.255:7359"
+	discoveryMessage = "who is JellyfinServer?"
+)
+
+// DiscoveredServer is a Jellyfin server that answered a discovery broadcast.
+type DiscoveredServer struct {
+	ID              string `json:"Id"`
+	Name            string `json:"Name"`
+	Address         string `json:"Address"`
+	EndpointAddress string `json:"EndpointAddress"`
+}
+
+// NewClient creates a Client for the discovered server.
+func (s DiscoveredServer) NewClient(clientName, clientVersion string, opts ...ClientOptionFunc) (*Client, error) {
+	return NewClient(s.Address, clientName, clientVersion, opts...)
+}
+
+// DiscoverServers broadcasts a discovery request on the local network and
+// returns the servers that answer within the timeout, deduplicated by server ID.
+func DiscoverServers(ctx context.Context, timeout time.Duration) ([]DiscoveredServer, error) {
+	return discoverServers(ctx, timeout, discoveryAddress)
+}
+
+func discoverServers(ctx context.Context, timeout time.Duration, address string) ([]DiscoveredServer, error) {
+	addr, err := net.ResolveUDPAddr("udp4", address)
+	if err != nil {
+		return nil, fmt.Errorf("discover servers: %v", err)
+	}
+	conn, err := net.ListenUDP("udp4", nil)
+	if err != nil {
+		return nil, fmt.Errorf("discover servers: %v", err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	conn.SetReadDeadline(deadline)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			// unblock the read
+			conn.SetReadDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	if _, err := conn.WriteToUDP([]byte(discoveryMessage), addr); err != nil {
+		return nil, fmt.Errorf("discover servers: %v", err)
+	}
+
+	var servers []DiscoveredServer
+	seen := make(map[string]bool)
+	buf := make([]byte, 4096)
+	for {
+		n, _, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil && !errors.Is(ctxErr, context.DeadlineExceeded) {
+				return nil, ctxErr
+			}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				return servers, nil
+			}
+			return servers, fmt.Errorf("discover servers: %v", err)
+		}
+		var s DiscoveredServer
+		if err := json.Unmarshal(buf[:n], &s); err != nil || s.ID == "" || s.Address == "" {
+			// not a Jellyfin server response
+			continue
+		}
+		if !seen[s.ID] {
+			seen[s.ID] = true
+			servers = append(servers, s)
+		}
+	}
+}
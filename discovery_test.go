@@ -0,0 +1,74 @@
+package jellyfin
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiscoverServers(t *testing.T) {
+	responder, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer responder.Close()
+
+	servers := []DiscoveredServer{
+		{ID: "server1", Name: "Living Room", Address: "http://192.168.1.10:8096"},
+		{ID: "server2", Name: "Attic", Address: "http://192.168.1.11:8096", EndpointAddress: "192.168.1.11"},
+	}
+	go func() {
+		buf := make([]byte, 1024)
+		n, from, err := responder.ReadFromUDP(buf)
+		if err != nil || string(buf[:n]) != discoveryMessage {
+			return
+		}
+		responder.WriteToUDP([]byte("garbage"), from)
+		// the first server answers twice, e.g. on two network interfaces
+		for _, s := range []DiscoveredServer{servers[0], servers[0], servers[1]} {
+			b, _ := json.Marshal(s)
+			responder.WriteToUDP(b, from)
+		}
+	}()
+
+	got, err := discoverServers(context.Background(), 200*time.Millisecond, responder.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, servers) {
+		t.Errorf("got servers %+v, want %+v", got, servers)
+	}
+
+	client, err := got[0].NewClient("test", "1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u := client.BaseURL().String(); u != "http://192.168.1.10:8096/" {
+		t.Errorf("got client URL %s", u)
+	}
+}
+
+func TestDiscoverServers_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	// the responder never answers, so only cancellation ends the discovery early
+	silent, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer silent.Close()
+	start := time.Now()
+	_, err = discoverServers(ctx, 10*time.Second, silent.LocalAddr().String())
+	if err != context.Canceled {
+		t.Errorf("got error %v, want context.Canceled", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Error("discovery was not cancelled")
+	}
+}
@@ -0,0 +1,145 @@
+package jellyfin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const folderSongsPageSize = 500
+
+// folderItemTypes are the item types a folder listing can contain.
+var folderItemTypes = []string{
+	string(mediaTypeFolder), string(folderTypeCollections), string(mediaTypeAlbum), string(mediaTypeAudio),
+}
+
+var folderIncludeFields = []string{"Path", "ChildCount", "DateCreated", "ParentId", "MediaSources", "Genres", "UserData"}
+
+var folderFields = fieldSet{
+	list:     []string{"Path", "ChildCount", "DateCreated"},
+	standard: folderIncludeFields,
+	detail:   append(append([]string{}, folderIncludeFields...), "ProviderIds", "People"),
+}
+
+// GetFolderItems returns the direct children of a folder in the file system hierarchy.
+// Use the ID of a music library (from GetUserViews) as the parentID to list its root.
+// Unless a sort is given, folders are listed first, then items, each by name.
+func (c *Client) GetFolderItems(ctx context.Context, parentID string, opts QueryOpts) ([]FolderItem, error) {
+	params := c.defaultParams()
+	params.setPaging(opts.Paging)
+	sort := opts.Sort
+	if sort.isZero() {
+		sort.Keys = []SortKey{{Field: SortByIsFolder, Mode: SortDesc}, {Field: SortByName, Mode: SortAsc}}
+	}
+	params.setSorting(sort)
+	if err := params.setFilter(mediaTypeFolder, opts.Filter); err != nil {
+		return nil, err
+	}
+	params.setResponse(opts.Response, folderFields)
+	// filter on the server, so that skipped items do not shorten pages
+	params.setList("IncludeItemTypes", folderItemTypes, ",")
+	params["ParentId"] = parentID
+	resp, err := c.getContext(ctx, fmt.Sprintf("/Users/%s/Items", c.userID), params)
+	if err != nil {
+		return nil, fmt.Errorf("get folder items: %v", err)
+	}
+	defer resp.Close()
+
+	dto := struct {
+		Items []json.RawMessage `json:"Items"`
+	}{}
+	if err := json.NewDecoder(resp).Decode(&dto); err != nil {
+		return nil, fmt.Errorf("decode json: %v", err)
+	}
+
+	result := make([]FolderItem, 0, len(dto.Items))
+	for _, raw := range dto.Items {
+		item, ok, err := decodeFolderItem(raw)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// GetAncestors returns the parent folders of an item, from its direct parent
+// up to the root. It can be used to display breadcrumbs.
+func (c *Client) GetAncestors(itemID string) ([]*Folder, error) {
+	resp, err := c.get(fmt.Sprintf("/Items/%s/Ancestors", itemID), c.defaultParams())
+	if err != nil {
+		return nil, fmt.Errorf("get ancestors: %v", err)
+	}
+	defer resp.Close()
+
+	var folders []*Folder
+	if err := json.NewDecoder(resp).Decode(&folders); err != nil {
+		return nil, fmt.Errorf("decode json: %v", err)
+	}
+	return folders, nil
+}
+
+// GetFolderSongs returns all songs contained in the folder and its subfolders,
+// ordered by album, disc and track number, e.g. to play or queue a whole folder.
+func (c *Client) GetFolderSongs(ctx context.Context, folderID string) ([]*Song, error) {
+	params := c.defaultParams()
+	params.enableRecursive()
+	params.setIncludeTypes(mediaTypeAudio)
+	params.setIncludeFields(songIncludeFields...)
+	params["ParentId"] = folderID
+	params.setSorting(Sort{Keys: []SortKey{
+		{Field: SortByAlbum}, {Field: SortByParentIndexNumber}, {Field: SortByIndexNumber}, {Field: SortByName},
+	}})
+
+	var all []*Song
+	for start := 0; ; start += folderSongsPageSize {
+		params.setPaging(Paging{StartIndex: start, Limit: folderSongsPageSize})
+		resp, err := c.getContext(ctx, fmt.Sprintf("/Users/%s/Items", c.userID), params)
+		if err != nil {
+			return nil, fmt.Errorf("get folder songs: %v", err)
+		}
+		songs, err := c.parseSongs(resp)
+		resp.Close()
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, songs...)
+		if len(songs) < folderSongsPageSize {
+			return all, nil
+		}
+	}
+}
+
+// decodeFolderItem decodes a folder listing entry according to its type.
+// Items of other types than folderItemTypes are skipped.
+func decodeFolderItem(raw json.RawMessage) (FolderItem, bool, error) {
+	var header struct {
+		Type     string `json:"Type"`
+		IsFolder bool   `json:"IsFolder"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return FolderItem{}, false, fmt.Errorf("decode folder item: %v", err)
+	}
+
+	var item FolderItem
+	var dto interface{}
+	switch {
+	case header.Type == string(mediaTypeAlbum):
+		item.Album = &Album{}
+		dto = item.Album
+	case header.Type == string(mediaTypeAudio):
+		item.Song = &Song{}
+		dto = item.Song
+	case header.IsFolder:
+		item.Folder = &Folder{}
+		dto = item.Folder
+	default:
+		return FolderItem{}, false, nil
+	}
+	if err := json.Unmarshal(raw, dto); err != nil {
+		return FolderItem{}, false, fmt.Errorf("decode folder item: %v", err)
+	}
+	return item, true, nil
+}
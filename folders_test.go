@@ -0,0 +1,47 @@
+package jellyfin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestGetFolderItems(t *testing.T) {
+	var query url.Values
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		json.NewEncoder(w).Encode(map[string]any{"Items": []map[string]any{
+			{"Id": "f1", "Type": "Folder", "IsFolder": true},
+			{"Id": "al1", "Type": "MusicAlbum", "IsFolder": true},
+			{"Id": "s1", "Type": "Audio"},
+			{"Id": "v1", "Type": "Video"},
+		}})
+	})
+
+	items, err := c.GetFolderItems(context.Background(), "lib", QueryOpts{Paging: Paging{StartIndex: 50, Limit: 25}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 || items[0].Folder.ID != "f1" || items[1].Album.ID != "al1" || items[2].Song.Id != "s1" {
+		t.Errorf("got items %+v, want folder, album and song", items)
+	}
+
+	if query.Get("ParentId") != "lib" || query.Get("StartIndex") != "50" || query.Get("Limit") != "25" {
+		t.Errorf("got query %v", query)
+	}
+	if query.Get("Recursive") != "" {
+		t.Errorf("folder listing must not be recursive: %v", query)
+	}
+	types := "," + query.Get("IncludeItemTypes") + ","
+	for _, want := range []string{"Folder", "MusicAlbum", "Audio"} {
+		if !strings.Contains(types, ","+want+",") {
+			t.Errorf("got item types %s, want %s included", types, want)
+		}
+	}
+	if query.Get("SortBy") != "IsFolder,SortName" {
+		t.Errorf("got sort %q, want folders first", query.Get("SortBy"))
+	}
+}
@@ -3,3 +3,5 @@ module github.com/dweymouth/go-jellyfin
 go 1.20
 
 require golang.org/x/image v0.14.0
+
+require github.com/coder/websocket v1.8.12
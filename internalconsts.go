@@ -11,6 +11,18 @@ const (
 	folderTypePlaylists   mediaItemType = "PlaylistsFolder"
 	folderTypeCollections mediaItemType = "CollectionFolder"
 	mediaTypeGenre        mediaItemType = "Genre"
+	mediaTypeBoxSet       mediaItemType = "BoxSet"
+	mediaTypeMovie        mediaItemType = "Movie"
+	mediaTypeSeries       mediaItemType = "Series"
+	mediaTypeSeason       mediaItemType = "Season"
+	mediaTypeEpisode      mediaItemType = "Episode"
+	mediaTypeAudioBook    mediaItemType = "AudioBook"
+	mediaTypeMusicVideo   mediaItemType = "MusicVideo"
+	mediaTypeFolder       mediaItemType = "Folder"
+)
+
+const (
+	personTypeComposer = "Composer"
 )
 
 const (
@@ -0,0 +1,353 @@
+// Package library maintains a local mirror of a Jellyfin music library.
+//
+// The mirror downloads all artists, albums, songs, playlists and genres once,
+// persists them to a file, and is then kept up to date incrementally. Queries
+// take the same options as the corresponding jellyfin.Client calls, but are
+// evaluated locally, so they work offline and without network latency.
+package library
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	jellyfin "github.com/dweymouth/go-jellyfin"
+)
+
+const (
+	pageSize = 1000
+
+	// clockSkew is subtracted from the last sync time in delta syncs,
+	// to tolerate differences between the client and server clocks.
+	clockSkew = 5 * time.Minute
+)
+
+var ErrNotFound = errors.New("item not found in library")
+
+var (
+	// songResponse leaves out People, which is costly for the server to
+	// compute for every song, so the mirrored songs have no Composers.
+	songResponse = jellyfin.ResponseOpts{
+		Fields:                  []string{"Genres", "DateCreated", "ParentId"},
+		ImageTypeLimit:          1,
+		DisableTotalRecordCount: true,
+	}
+	albumResponse = jellyfin.ResponseOpts{
+		Fields:                  []string{"Genres", "DateCreated", "ChildCount"},
+		ImageTypeLimit:          1,
+		DisableTotalRecordCount: true,
+	}
+	artistResponse = jellyfin.ResponseOpts{
+		Fields:                  []string{"ChildCount"},
+		ImageTypeLimit:          1,
+		DisableTotalRecordCount: true,
+	}
+)
+
+// Library is a local mirror of the music library of the logged-in user.
+// Items returned by queries are shared with the mirror and must not be modified.
+type Library struct {
+	client *jellyfin.Client
+	path   string
+
+	// syncMu serializes syncs, so that a slow sync cannot overwrite a newer one.
+	syncMu sync.Mutex
+
+	mu        sync.RWMutex
+	lastSync  time.Time
+	artists   map[string]*jellyfin.Artist
+	albums    map[string]*jellyfin.Album
+	songs     map[string]*jellyfin.Song
+	playlists []*jellyfin.Playlist
+	genres    []jellyfin.NameID
+
+	// index is built on the first Search after a change.
+	index *jellyfin.SearchIndex
+}
+
+// Open loads the mirror persisted at path, if any. The client must be logged in
+// as the user whose library is mirrored. Call Sync to download or refresh the library.
+func Open(client *jellyfin.Client, path string) (*Library, error) {
+	l := &Library{client: client, path: path}
+	snap, err := loadSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+	l.restore(snap)
+	return l, nil
+}
+
+// LastSync returns the time of the last successful sync, or the zero time if
+// the library has never been synced.
+func (l *Library) LastSync() time.Time {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.lastSync
+}
+
+// Sync refreshes the mirror and persists it. The first sync downloads the whole
+// library; later syncs only fetch songs and albums added or changed since the
+// last sync, including changes to user data such as plays and favorites.
+// Artists, playlists and genres are always refetched in full.
+//
+// Removed items are not detected by a delta sync. Run Watch to apply removals
+// as they happen, or FullSync to reconcile the mirror with the server.
+func (l *Library) Sync(ctx context.Context) error {
+	l.syncMu.Lock()
+	defer l.syncMu.Unlock()
+
+	since := l.LastSync()
+	if since.IsZero() {
+		return l.fullSync(ctx)
+	}
+	start := time.Now()
+	since = since.Add(-clockSkew)
+
+	var songs []*jellyfin.Song
+	var albums []*jellyfin.Album
+	for _, filter := range []jellyfin.Filter{{MinDateLastSaved: since}, {MinDateLastSavedForUser: since}} {
+		s, err := fetchAll(ctx, func(p jellyfin.Paging) ([]*jellyfin.Song, error) {
+			return l.client.GetSongs(jellyfin.QueryOpts{Paging: p, Filter: filter, Sort: fetchSort, Response: songResponse})
+		})
+		if err != nil {
+			return fmt.Errorf("sync songs: %w", err)
+		}
+		songs = append(songs, s...)
+
+		a, err := fetchAll(ctx, func(p jellyfin.Paging) ([]*jellyfin.Album, error) {
+			return l.client.GetAlbums(jellyfin.QueryOpts{Paging: p, Filter: filter, Sort: fetchSort, Response: albumResponse})
+		})
+		if err != nil {
+			return fmt.Errorf("sync albums: %w", err)
+		}
+		albums = append(albums, a...)
+	}
+	snap, err := l.fetchSmall(ctx)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	for _, s := range songs {
+		l.songs[s.Id] = s
+	}
+	for _, a := range albums {
+		l.albums[a.ID] = a
+	}
+	l.artists = byID(snap.Artists, func(a *jellyfin.Artist) string { return a.ID })
+	l.playlists = snap.Playlists
+	l.genres = snap.Genres
+	l.lastSync = start
+	l.index = nil
+	l.mu.Unlock()
+
+	return l.save()
+}
+
+// FullSync downloads the whole library, replacing the mirror.
+func (l *Library) FullSync(ctx context.Context) error {
+	l.syncMu.Lock()
+	defer l.syncMu.Unlock()
+	return l.fullSync(ctx)
+}
+
+func (l *Library) fullSync(ctx context.Context) error {
+	start := time.Now()
+	snap, err := l.fetchSmall(ctx)
+	if err != nil {
+		return err
+	}
+	snap.Songs, err = fetchAll(ctx, func(p jellyfin.Paging) ([]*jellyfin.Song, error) {
+		return l.client.GetSongs(jellyfin.QueryOpts{Paging: p, Sort: fetchSort, Response: songResponse})
+	})
+	if err != nil {
+		return fmt.Errorf("sync songs: %w", err)
+	}
+	snap.Albums, err = fetchAll(ctx, func(p jellyfin.Paging) ([]*jellyfin.Album, error) {
+		return l.client.GetAlbums(jellyfin.QueryOpts{Paging: p, Sort: fetchSort, Response: albumResponse})
+	})
+	if err != nil {
+		return fmt.Errorf("sync albums: %w", err)
+	}
+	snap.LastSync = start
+
+	l.restore(snap)
+	return l.save()
+}
+
+// fetchSmall fetches the artists, playlists and genres, which
+// are few enough compared to songs to always fetch in full.
+func (l *Library) fetchSmall(ctx context.Context) (*snapshot, error) {
+	snap := &snapshot{}
+	var err error
+	snap.Artists, err = fetchAll(ctx, func(p jellyfin.Paging) ([]*jellyfin.Artist, error) {
+		return l.client.GetArtists(jellyfin.QueryOpts{Paging: p, Sort: fetchSort, Response: artistResponse})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sync artists: %w", err)
+	}
+	snap.Genres, err = fetchAll(ctx, func(p jellyfin.Paging) ([]jellyfin.NameID, error) {
+		return l.client.GetGenres(p, "")
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sync genres: %w", err)
+	}
+	if snap.Playlists, err = l.client.GetPlaylists(); err != nil {
+		return nil, fmt.Errorf("sync playlists: %w", err)
+	}
+	return snap, nil
+}
+
+// Watch applies library changes pushed by the server until ctx is done or the
+// connection is lost. Removed items are deleted from the mirror, and added or
+// updated items trigger a delta sync, both in a background goroutine.
+// Failures of background syncs and saves are passed to onError, if non-nil;
+// the changes of a failed sync are picked up by the next successful Sync.
+func (l *Library) Watch(ctx context.Context, onError func(error)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		removed  []string
+		needSync bool
+	)
+	wake := make(chan struct{}, 1)
+	report := func(err error) {
+		if err != nil && ctx.Err() == nil && onError != nil {
+			onError(err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-wake:
+			}
+			mu.Lock()
+			ids, doSync := removed, needSync
+			removed, needSync = nil, false
+			mu.Unlock()
+
+			if len(ids) > 0 {
+				// saves only happen under syncMu, so an older snapshot
+				// can never overwrite a newer one
+				l.syncMu.Lock()
+				l.remove(ids)
+				err := l.save()
+				l.syncMu.Unlock()
+				report(err)
+			}
+			if doSync {
+				report(l.Sync(ctx))
+			}
+		}
+	}()
+
+	err := l.client.WatchLibraryChanges(ctx, func(change jellyfin.LibraryChange) {
+		mu.Lock()
+		removed = append(removed, change.ItemsRemoved...)
+		needSync = needSync || len(change.ItemsAdded) > 0 || len(change.ItemsUpdated) > 0
+		mu.Unlock()
+		select {
+		case wake <- struct{}{}:
+		default:
+			// the worker is already woken
+		}
+	})
+	cancel()
+	wg.Wait()
+	return err
+}
+
+func (l *Library) remove(ids []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	removed := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		removed[id] = true
+		delete(l.songs, id)
+		delete(l.albums, id)
+		delete(l.artists, id)
+	}
+	l.index = nil
+	playlists := l.playlists[:0:0]
+	for _, p := range l.playlists {
+		if !removed[p.ID] {
+			playlists = append(playlists, p)
+		}
+	}
+	l.playlists = playlists
+}
+
+func (l *Library) restore(snap *snapshot) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lastSync = snap.LastSync
+	l.artists = byID(snap.Artists, func(a *jellyfin.Artist) string { return a.ID })
+	l.albums = byID(snap.Albums, func(a *jellyfin.Album) string { return a.ID })
+	l.songs = byID(snap.Songs, func(s *jellyfin.Song) string { return s.Id })
+	l.playlists = snap.Playlists
+	l.genres = snap.Genres
+	l.index = nil
+}
+
+// save persists the mirror. It must be called with syncMu held.
+func (l *Library) save() error {
+	l.mu.RLock()
+	snap := &snapshot{
+		Version:   snapshotVersion,
+		LastSync:  l.lastSync,
+		Artists:   values(l.artists),
+		Albums:    values(l.albums),
+		Songs:     values(l.songs),
+		Playlists: l.playlists,
+		Genres:    l.genres,
+	}
+	l.mu.RUnlock()
+	return saveSnapshot(l.path, snap)
+}
+
+// fetchSort gives a stable order for paging through all items.
+var fetchSort = jellyfin.Sort{Field: jellyfin.SortByDateCreated, Mode: jellyfin.SortAsc}
+
+// fetchAll pages through all items returned by fetch.
+func fetchAll[T any](ctx context.Context, fetch func(jellyfin.Paging) ([]T, error)) ([]T, error) {
+	var all []T
+	for start := 0; ; start += pageSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		page, err := fetch(jellyfin.Paging{StartIndex: start, Limit: pageSize})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if len(page) < pageSize {
+			return all, nil
+		}
+	}
+}
+
+func byID[T any](items []T, idOf func(T) string) map[string]T {
+	m := make(map[string]T, len(items))
+	for _, item := range items {
+		m[idOf(item)] = item
+	}
+	return m
+}
+
+func values[T any](m map[string]T) []T {
+	v := make([]T, 0, len(m))
+	for _, item := range m {
+		v = append(v, item)
+	}
+	return v
+}
@@ -0,0 +1,168 @@
+package library
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	jellyfin "github.com/dweymouth/go-jellyfin"
+)
+
+func testLibrary() *Library {
+	l := &Library{}
+	l.restore(&snapshot{
+		Songs: []*jellyfin.Song{
+			{Id: "s1", Name: "The Beginning", AlbumID: "a1", IndexNumber: 1, ProductionYear: 1999,
+				Artists: []jellyfin.NameID{{ID: "ar1", Name: "Alpha"}}, Genres: []string{"Rock"}},
+			{Id: "s2", Name: "Middle", AlbumID: "a1", IndexNumber: 2, ProductionYear: 1999,
+				Artists: []jellyfin.NameID{{ID: "ar1", Name: "Alpha"}}, Genres: []string{"Rock"},
+				UserData: jellyfin.UserData{IsFavorite: true, PlayCount: 5, Played: true}},
+			{Id: "s3", Name: "Ending", AlbumID: "a2", IndexNumber: 1, ProductionYear: 2010,
+				Artists: []jellyfin.NameID{{ID: "ar2", Name: "Beta"}}, Genres: []string{"Jazz"},
+				UserData: jellyfin.UserData{PlayCount: 2, Played: true}},
+		},
+		Albums: []*jellyfin.Album{
+			{ID: "a1", Name: "First", Year: 1999, Artists: []jellyfin.NameID{{ID: "ar1", Name: "Alpha"}}},
+			{ID: "a2", Name: "Second", Year: 2010, Artists: []jellyfin.NameID{{ID: "ar2", Name: "Beta"}}},
+		},
+		Artists: []*jellyfin.Artist{{ID: "ar1", Name: "Alpha"}, {ID: "ar2", Name: "Beta"}},
+	})
+	return l
+}
+
+func songIDs(songs []*jellyfin.Song) []string {
+	ids := make([]string, len(songs))
+	for i, s := range songs {
+		ids[i] = s.Id
+	}
+	return ids
+}
+
+func TestLibrary_GetSongs(t *testing.T) {
+	l := testLibrary()
+	tests := []struct {
+		name string
+		opts jellyfin.QueryOpts
+		want []string
+	}{
+		{
+			name: "default sort by name ignores article",
+			want: []string{"s1", "s3", "s2"},
+		},
+		{
+			name: "album track list",
+			opts: jellyfin.QueryOpts{
+				Filter: jellyfin.Filter{ParentID: "a1"},
+				Sort:   jellyfin.Sort{Field: jellyfin.SortByIndexNumber, Mode: jellyfin.SortAsc},
+			},
+			want: []string{"s1", "s2"},
+		},
+		{
+			name: "most played first",
+			opts: jellyfin.QueryOpts{Sort: jellyfin.Sort{Field: jellyfin.SortByPlayCount, Mode: jellyfin.SortDesc}},
+			want: []string{"s2", "s3", "s1"},
+		},
+		{
+			name: "multiple keys",
+			opts: jellyfin.QueryOpts{Sort: jellyfin.Sort{Keys: []jellyfin.SortKey{
+				{Field: jellyfin.SortByYear, Mode: jellyfin.SortDesc},
+				{Field: jellyfin.SortByIndexNumber, Mode: jellyfin.SortDesc},
+			}}},
+			want: []string{"s3", "s2", "s1"},
+		},
+		{
+			name: "filters",
+			opts: jellyfin.QueryOpts{Filter: jellyfin.Filter{
+				FilterPlayed: jellyfin.FilterIsPlayed,
+				Genres:       []string{"rock", "jazz"},
+				YearRange:    [2]int{1990, 2000},
+			}},
+			want: []string{"s2"},
+		},
+		{
+			name: "artist and exclusions",
+			opts: jellyfin.QueryOpts{Filter: jellyfin.Filter{ArtistIDs: []string{"ar1", "ar2"}, ExcludeItemIDs: []string{"s1"}}},
+			want: []string{"s3", "s2"},
+		},
+		{
+			name: "paging",
+			opts: jellyfin.QueryOpts{Paging: jellyfin.Paging{StartIndex: 1, Limit: 1}},
+			want: []string{"s3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			songs, err := l.GetSongs(tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := songIDs(songs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLibrary_Query_Unsupported(t *testing.T) {
+	l := testLibrary()
+	if _, err := l.GetSongs(jellyfin.QueryOpts{Filter: jellyfin.Filter{Tags: []string{"x"}}}); err == nil {
+		t.Error("expected error for Tags filter")
+	}
+	if _, err := l.GetArtists(jellyfin.QueryOpts{Filter: jellyfin.Filter{Genres: []string{"Rock"}}}); err == nil {
+		t.Error("expected error for Genres filter on artists")
+	}
+	if _, err := l.GetAlbums(jellyfin.QueryOpts{Sort: jellyfin.Sort{Field: jellyfin.SortByStudio}}); err == nil {
+		t.Error("expected error for sort by studio")
+	}
+}
+
+func TestLibrary_SeededRandom(t *testing.T) {
+	l := testLibrary()
+	opts := jellyfin.QueryOpts{Sort: jellyfin.Sort{Field: jellyfin.SortByRandom, Seed: 42}}
+	first, _ := l.GetSongs(opts)
+	second, _ := l.GetSongs(opts)
+	if !reflect.DeepEqual(songIDs(first), songIDs(second)) {
+		t.Errorf("seeded random order differs: %v, %v", songIDs(first), songIDs(second))
+	}
+}
+
+func TestLibrary_RemoveAndPersist(t *testing.T) {
+	l := testLibrary()
+	l.path = filepath.Join(t.TempDir(), "library.json.gz")
+	l.lastSync = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	l.remove([]string{"s2", "a2"})
+	if err := l.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := Open(nil, l.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reopened.LastSync().Equal(l.lastSync) {
+		t.Errorf("got last sync %v, want %v", reopened.LastSync(), l.lastSync)
+	}
+	songs, _ := reopened.GetSongs(jellyfin.QueryOpts{})
+	if got, want := songIDs(songs), []string{"s1", "s3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got songs %v, want %v", got, want)
+	}
+	if _, err := reopened.GetAlbum("a2"); err != ErrNotFound {
+		t.Errorf("got error %v for removed album, want ErrNotFound", err)
+	}
+	if a, err := reopened.GetAlbum("a1"); err != nil || a.Name != "First" {
+		t.Errorf("got album %+v (err %v), want First", a, err)
+	}
+}
+
+func TestLibrary_Search(t *testing.T) {
+	l := testLibrary()
+	res := l.Search("begining", 10)
+	if got := songIDs(res.Songs); !reflect.DeepEqual(got, []string{"s1"}) {
+		t.Errorf("got songs %v, want [s1]", got)
+	}
+	l.remove([]string{"s1"})
+	if res := l.Search("begining", 10); len(res.Songs) != 0 {
+		t.Errorf("got songs %v after removal, want none", songIDs(res.Songs))
+	}
+}
@@ -0,0 +1,466 @@
+package library
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	jellyfin "github.com/dweymouth/go-jellyfin"
+)
+
+type itemKind string
+
+const (
+	kindSong   itemKind = "songs"
+	kindAlbum  itemKind = "albums"
+	kindArtist itemKind = "artists"
+)
+
+// item is the common view of a mirrored song, album or artist
+// that filters and sorting are evaluated on.
+type item struct {
+	id          string
+	name        string // sort name, see sortName
+	title       string // lower-cased name
+	artistIDs   []string
+	albumID     string
+	genres      []string
+	year        int
+	album       string
+	albumArtist string
+	trackArtist string
+	dateCreated string
+	runtime     int64
+	index       int
+	disc        int
+	userData    jellyfin.UserData
+}
+
+// GetSongs returns the mirrored songs matching the filter, with given sorting and paging.
+// The supported filters are play status, favorite, artists, album (ParentID or AlbumIDs),
+// genres, year range, exclusions and names. Other filters return an error.
+func (l *Library) GetSongs(opts jellyfin.QueryOpts) ([]*jellyfin.Song, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return query(l.songs, kindSong, songItem, opts)
+}
+
+// GetAlbums returns the mirrored albums matching the filter, with given sorting and paging.
+// The supported filters are play status, favorite, artists, album IDs, genres,
+// year range, exclusions and names. Other filters return an error.
+func (l *Library) GetAlbums(opts jellyfin.QueryOpts) ([]*jellyfin.Album, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return query(l.albums, kindAlbum, albumItem, opts)
+}
+
+// GetArtists returns the mirrored artists matching the filter, with given sorting and paging.
+// The supported filters are favorite, excluded items and names. Other filters return an error.
+func (l *Library) GetArtists(opts jellyfin.QueryOpts) ([]*jellyfin.Artist, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return query(l.artists, kindArtist, artistItem, opts)
+}
+
+// GetPlaylists returns all mirrored playlists.
+func (l *Library) GetPlaylists() ([]*jellyfin.Playlist, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return append([]*jellyfin.Playlist{}, l.playlists...), nil
+}
+
+// GetGenres returns the mirrored music genres sorted by name.
+// Filtering by parentID is not supported locally.
+func (l *Library) GetGenres(paging jellyfin.Paging, parentID string) ([]jellyfin.NameID, error) {
+	if parentID != "" {
+		return nil, fmt.Errorf("library: genres of a parent are not supported locally")
+	}
+	l.mu.RLock()
+	genres := append([]jellyfin.NameID{}, l.genres...)
+	l.mu.RUnlock()
+	sort.SliceStable(genres, func(i, j int) bool {
+		return sortName(genres[i].Name) < sortName(genres[j].Name)
+	})
+	return page(genres, paging), nil
+}
+
+func (l *Library) GetSong(songID string) (*jellyfin.Song, error) {
+	return get(l, func() map[string]*jellyfin.Song { return l.songs }, songID)
+}
+
+func (l *Library) GetAlbum(albumID string) (*jellyfin.Album, error) {
+	return get(l, func() map[string]*jellyfin.Album { return l.albums }, albumID)
+}
+
+func (l *Library) GetArtist(artistID string) (*jellyfin.Artist, error) {
+	return get(l, func() map[string]*jellyfin.Artist { return l.artists }, artistID)
+}
+
+// get looks up an item by ID. The map is selected while holding the lock,
+// since syncs replace the maps.
+func get[T any](l *Library, items func() map[string]T, id string) (T, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	item, ok := items()[id]
+	if !ok {
+		return item, ErrNotFound
+	}
+	return item, nil
+}
+
+func query[T any](all map[string]T, kind itemKind, view func(T) item, opts jellyfin.QueryOpts) ([]T, error) {
+	if err := checkFilter(kind, opts.Filter); err != nil {
+		return nil, err
+	}
+	keys, err := sortKeys(opts.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []item
+	var matched []T
+	for _, t := range all {
+		if v := view(t); matches(&v, &opts.Filter) {
+			items = append(items, v)
+			matched = append(matched, t)
+		}
+	}
+
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	// sort by ID first, so that ties and shuffles do not depend on map order
+	sort.Slice(order, func(i, j int) bool { return items[order[i]].id < items[order[j]].id })
+	if len(keys) == 1 && keys[0].Field == jellyfin.SortByRandom {
+		seed := opts.Sort.Seed
+		if seed == 0 {
+			seed = time.Now().UnixNano()
+		}
+		rand.New(rand.NewSource(seed)).Shuffle(len(order), func(i, j int) {
+			order[i], order[j] = order[j], order[i]
+		})
+	} else {
+		sort.SliceStable(order, func(i, j int) bool {
+			a, b := &items[order[i]], &items[order[j]]
+			for _, k := range keys {
+				c := compareField(k.Field, a, b)
+				if k.Mode == jellyfin.SortDesc {
+					c = -c
+				}
+				if c != 0 {
+					return c < 0
+				}
+			}
+			return false
+		})
+	}
+
+	sorted := make([]T, len(order))
+	for i, idx := range order {
+		sorted[i] = matched[idx]
+	}
+	return page(sorted, opts.Paging), nil
+}
+
+func page[T any](items []T, paging jellyfin.Paging) []T {
+	start := paging.StartIndex
+	if start > len(items) {
+		start = len(items)
+	}
+	end := len(items)
+	if paging.Limit > 0 && start+paging.Limit < end {
+		end = start + paging.Limit
+	}
+	return items[start:end]
+}
+
+// checkFilter rejects filters that are invalid or cannot be evaluated locally.
+func checkFilter(kind itemKind, f jellyfin.Filter) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	unsupported := []struct {
+		name string
+		set  bool
+	}{
+		{"GenreIDs", len(f.GenreIDs) > 0},
+		{"Tags", len(f.Tags) > 0},
+		{"Studios", len(f.Studios) > 0},
+		{"MinCommunityRating", f.MinCommunityRating != 0},
+		{"MinDateLastSaved", !f.MinDateLastSaved.IsZero()},
+		{"MinDateLastSavedForUser", !f.MinDateLastSavedForUser.IsZero()},
+		{"MinPremiereDate", !f.MinPremiereDate.IsZero()},
+		{"MaxPremiereDate", !f.MaxPremiereDate.IsZero()},
+		{"HasLyrics", f.HasLyrics},
+		{"Containers", len(f.Containers) > 0},
+		{"AudioCodecs", len(f.AudioCodecs) > 0},
+		{"Resolution", f.Resolution != jellyfin.ResolutionAny},
+		{"HDR", f.HDR},
+		{"MaxParentalRating", f.MaxParentalRating != ""},
+		{"ParentID", f.ParentID != "" && kind != kindSong},
+		{"ArtistID", (f.ArtistID != "" || len(f.ArtistIDs) > 0 || len(f.ExcludeArtistIDs) > 0) && kind == kindArtist},
+		{"AlbumIDs", len(f.AlbumIDs) > 0 && kind == kindArtist},
+		{"Genres", len(f.Genres) > 0 && kind == kindArtist},
+		{"YearRange", f.YearRange != [2]int{} && kind == kindArtist},
+		{"FilterPlayed", f.FilterPlayed != "" && kind == kindArtist},
+	}
+	for _, u := range unsupported {
+		if u.set {
+			return fmt.Errorf("library: filter %s is not supported locally for %s", u.name, kind)
+		}
+	}
+	return nil
+}
+
+func matches(v *item, f *jellyfin.Filter) bool {
+	switch f.FilterPlayed {
+	case jellyfin.FilterIsPlayed:
+		if !v.userData.Played {
+			return false
+		}
+	case jellyfin.FilterIsNotPlayed:
+		if v.userData.Played {
+			return false
+		}
+	}
+	if f.Favorite && !v.userData.IsFavorite {
+		return false
+	}
+	if f.ArtistID != "" || len(f.ArtistIDs) > 0 {
+		artistIDs := f.ArtistIDs
+		if f.ArtistID != "" {
+			artistIDs = append([]string{f.ArtistID}, artistIDs...)
+		}
+		if !containsAny(v.artistIDs, artistIDs) {
+			return false
+		}
+	}
+	if containsAny(v.artistIDs, f.ExcludeArtistIDs) || contains(f.ExcludeItemIDs, v.id) {
+		return false
+	}
+	if f.ParentID != "" && v.albumID != f.ParentID {
+		return false
+	}
+	if len(f.AlbumIDs) > 0 && !contains(f.AlbumIDs, v.albumID) {
+		return false
+	}
+	if len(f.Genres) > 0 && !containsAnyFold(v.genres, f.Genres) {
+		return false
+	}
+	if f.YearRange != [2]int{} && (v.year < f.YearRange[0] || v.year > f.YearRange[1]) {
+		return false
+	}
+	if f.NameStartsWith != "" && !strings.HasPrefix(v.name, sortName(f.NameStartsWith)) {
+		return false
+	}
+	if f.NameLessThan != "" && v.name >= sortName(f.NameLessThan) {
+		return false
+	}
+	return true
+}
+
+// sortKeys returns the sort keys of s, defaulting to ascending by name.
+func sortKeys(s jellyfin.Sort) ([]jellyfin.SortKey, error) {
+	keys := s.Keys
+	if len(keys) == 0 {
+		if s.Field == "" {
+			return []jellyfin.SortKey{{Field: jellyfin.SortByName}}, nil
+		}
+		keys = []jellyfin.SortKey{{Field: s.Field, Mode: s.Mode}}
+	}
+	for _, k := range keys {
+		if k.Field == jellyfin.SortByRandom && len(keys) > 1 {
+			return nil, fmt.Errorf("library: random order cannot be combined with other sort keys")
+		}
+		if k.Field != jellyfin.SortByRandom && compareField(k.Field, &item{}, &item{}) == unsupportedSort {
+			return nil, fmt.Errorf("library: sort by %s is not supported locally", k.Field)
+		}
+	}
+	return keys, nil
+}
+
+// unsupportedSort is returned by compareField for fields it cannot sort by.
+const unsupportedSort = 2
+
+func compareField(f jellyfin.SortField, a, b *item) int {
+	switch f {
+	case jellyfin.SortByName, jellyfin.SortByDefault:
+		return strings.Compare(a.name, b.name)
+	case jellyfin.SortByTitle:
+		return strings.Compare(a.title, b.title)
+	case jellyfin.SortByYear, jellyfin.SortByProductionYear:
+		return compareInt(int64(a.year), int64(b.year))
+	case jellyfin.SortByAlbumArtist:
+		return strings.Compare(a.albumArtist, b.albumArtist)
+	case jellyfin.SortByTrackArtist:
+		return strings.Compare(a.trackArtist, b.trackArtist)
+	case jellyfin.SortByAlbum:
+		return strings.Compare(a.album, b.album)
+	case jellyfin.SortByPlayCount:
+		return compareInt(int64(a.userData.PlayCount), int64(b.userData.PlayCount))
+	case jellyfin.SortByDateCreated:
+		return strings.Compare(a.dateCreated, b.dateCreated)
+	case jellyfin.SortByDatePlayed:
+		return strings.Compare(a.userData.LastPlayedDate, b.userData.LastPlayedDate)
+	case jellyfin.SortByRuntime:
+		return compareInt(a.runtime, b.runtime)
+	case jellyfin.SortByIndexNumber:
+		return compareInt(int64(a.index), int64(b.index))
+	case jellyfin.SortByParentIndexNumber:
+		return compareInt(int64(a.disc), int64(b.disc))
+	case jellyfin.SortByIsFavoriteOrLiked:
+		return compareBool(a.userData.IsFavorite, b.userData.IsFavorite)
+	case jellyfin.SortByIsPlayed:
+		return compareBool(a.userData.Played, b.userData.Played)
+	case jellyfin.SortByIsUnplayed:
+		return compareBool(!a.userData.Played, !b.userData.Played)
+	}
+	return unsupportedSort
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	}
+	return 1
+}
+
+// sortName approximates the server's sort name: lower-cased,
+// without a leading article.
+func sortName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, article := range []string{"the ", "a ", "an "} {
+		if strings.HasPrefix(name, article) {
+			return strings.TrimSpace(name[len(article):])
+		}
+	}
+	return name
+}
+
+func songItem(s *jellyfin.Song) item {
+	v := item{
+		id:          s.Id,
+		name:        sortName(s.Name),
+		title:       strings.ToLower(s.Name),
+		albumID:     s.AlbumID,
+		genres:      s.Genres,
+		year:        s.ProductionYear,
+		album:       sortName(s.Album),
+		dateCreated: s.DateCreated,
+		runtime:     s.RunTimeTicks,
+		index:       s.IndexNumber,
+		disc:        s.DiscNumber,
+		userData:    s.UserData,
+	}
+	for _, a := range s.Artists {
+		v.artistIDs = append(v.artistIDs, a.ID)
+	}
+	for _, a := range s.AlbumArtists {
+		v.artistIDs = append(v.artistIDs, a.ID)
+	}
+	if len(s.Artists) > 0 {
+		v.trackArtist = sortName(s.Artists[0].Name)
+	}
+	if len(s.AlbumArtists) > 0 {
+		v.albumArtist = sortName(s.AlbumArtists[0].Name)
+	} else {
+		v.albumArtist = v.trackArtist
+	}
+	return v
+}
+
+func albumItem(a *jellyfin.Album) item {
+	v := item{
+		id:          a.ID,
+		name:        sortName(a.Name),
+		title:       strings.ToLower(a.Name),
+		albumID:     a.ID,
+		genres:      a.Genres,
+		year:        a.Year,
+		album:       sortName(a.Name),
+		dateCreated: a.DateCreated,
+		runtime:     a.RunTimeTicks,
+		userData:    a.UserData,
+	}
+	for _, artist := range a.Artists {
+		v.artistIDs = append(v.artistIDs, artist.ID)
+	}
+	if len(a.Artists) > 0 {
+		v.albumArtist = sortName(a.Artists[0].Name)
+		v.trackArtist = v.albumArtist
+	}
+	return v
+}
+
+func artistItem(a *jellyfin.Artist) item {
+	return item{
+		id:       a.ID,
+		name:     sortName(a.Name),
+		title:    strings.ToLower(a.Name),
+		runtime:  a.RunTimeTicks,
+		userData: a.UserData,
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(list, values []string) bool {
+	for _, v := range values {
+		if contains(list, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAnyFold(list, values []string) bool {
+	for _, v := range values {
+		for _, l := range list {
+			if strings.EqualFold(l, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Search searches the mirrored songs, albums and artists with a fuzzy full-text
+// index that tolerates typos and accents, returning at most limit items of each
+// type (0 for no limit). See jellyfin.SearchIndex for how results are ranked.
+func (l *Library) Search(query string, limit int) *jellyfin.SearchResult {
+	l.mu.RLock()
+	idx := l.index
+	l.mu.RUnlock()
+	if idx == nil {
+		l.mu.Lock()
+		if l.index == nil {
+			l.index = jellyfin.NewSearchIndex(values(l.artists), values(l.albums), values(l.songs))
+		}
+		idx = l.index
+		l.mu.Unlock()
+	}
+	return idx.Search(query, limit)
+}
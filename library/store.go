@@ -0,0 +1,82 @@
+package library
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	jellyfin "github.com/dweymouth/go-jellyfin"
+)
+
+// snapshotVersion is increased when the file format changes.
+// Files of other versions are ignored, which causes a full sync.
+const snapshotVersion = 1
+
+// snapshot is the persisted form of the mirror, stored as gzipped JSON.
+type snapshot struct {
+	Version   int                  `json:"Version"`
+	LastSync  time.Time            `json:"LastSync"`
+	Artists   []*jellyfin.Artist   `json:"Artists"`
+	Albums    []*jellyfin.Album    `json:"Albums"`
+	Songs     []*jellyfin.Song     `json:"Songs"`
+	Playlists []*jellyfin.Playlist `json:"Playlists"`
+	Genres    []jellyfin.NameID    `json:"Genres"`
+}
+
+// loadSnapshot reads the snapshot at path. A missing file or a file of
+// another version yields an empty snapshot.
+func loadSnapshot(path string) (*snapshot, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &snapshot{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open library: %v", err)
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("read library: %v", err)
+	}
+	defer zr.Close()
+
+	snap := &snapshot{}
+	if err := json.NewDecoder(zr).Decode(snap); err != nil {
+		return nil, fmt.Errorf("decode library: %v", err)
+	}
+	if snap.Version != snapshotVersion {
+		return &snapshot{}, nil
+	}
+	return snap, nil
+}
+
+// saveSnapshot atomically replaces the file at path with the snapshot.
+func saveSnapshot(path string, snap *snapshot) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("save library: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	zw := gzip.NewWriter(tmp)
+	if err := json.NewEncoder(zw).Encode(snap); err != nil {
+		tmp.Close()
+		return fmt.Errorf("encode library: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("save library: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("save library: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("save library: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package library
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	jellyfin "github.com/dweymouth/go-jellyfin"
+)
+
+// fakeServer serves a library with a single song and album.
+func fakeServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var items []map[string]any
+		if strings.HasSuffix(r.URL.Path, "/Items") && r.URL.Query().Get("StartIndex") == "0" {
+			switch r.URL.Query().Get("IncludeItemTypes") {
+			case "Audio":
+				items = append(items, map[string]any{"Id": "s1", "Name": "Song", "AlbumId": "a1"})
+			case "MusicAlbum":
+				items = append(items, map[string]any{"Id": "a1", "Name": "Album"})
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"Items": items, "TotalRecordCount": len(items)})
+	}))
+}
+
+func testClient(t *testing.T, url string) *jellyfin.Client {
+	client, err := jellyfin.NewClient(url, "test", "1.0",
+		jellyfin.WithSession(jellyfin.Session{UserID: "user", Token: "token", DeviceID: "device"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestLibrary_ReadDuringSync(t *testing.T) {
+	server := fakeServer()
+	defer server.Close()
+	l, err := Open(testClient(t, server.URL), filepath.Join(t.TempDir(), "library.json.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 3; i++ {
+			if err := l.FullSync(context.Background()); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	// read until all syncs are done, so that reads overlap with the syncs replacing the maps
+	for reading := true; reading; {
+		select {
+		case <-done:
+			reading = false
+		default:
+		}
+		l.GetSong("s1")
+		l.GetAlbum("a1")
+		l.GetArtist("ar1")
+	}
+
+	if s, err := l.GetSong("s1"); err != nil || s.Name != "Song" {
+		t.Errorf("got song %+v (err %v) after sync", s, err)
+	}
+}
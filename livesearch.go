@@ -0,0 +1,154 @@
+package jellyfin
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+const liveSearchCacheSize = 32
+
+// LiveSearchResult is the outcome of a search for a single query.
+type LiveSearchResult struct {
+	Query  string
+	Result *SearchResult
+	Err    error
+}
+
+// LiveSearch runs search-as-you-type queries with SearchAll. Query updates are
+// debounced, in-flight searches for older queries are cancelled, and recent
+// results are cached. Only results for the latest query are delivered on Results,
+// so a slow response can never overwrite a newer one.
+type LiveSearch struct {
+	search   func(ctx context.Context, query string) (*SearchResult, error)
+	debounce time.Duration
+	results  chan LiveSearchResult
+
+	mu     sync.Mutex
+	seq    uint64
+	timer  *time.Timer
+	cancel context.CancelFunc
+	closed bool
+	keys   []string
+	cache  map[string]*SearchResult
+}
+
+// NewLiveSearch creates a LiveSearch that searches with the given options
+// once the query has not changed for the debounce duration.
+// Close must be called to release it when no longer needed.
+func NewLiveSearch(client *Client, opts SearchOptions, debounce time.Duration) *LiveSearch {
+	return newLiveSearch(func(ctx context.Context, query string) (*SearchResult, error) {
+		return client.SearchAll(ctx, query, opts)
+	}, debounce)
+}
+
+func newLiveSearch(search func(context.Context, string) (*SearchResult, error), debounce time.Duration) *LiveSearch {
+	return &LiveSearch{
+		search:   search,
+		debounce: debounce,
+		results:  make(chan LiveSearchResult, 1),
+		cache:    make(map[string]*SearchResult),
+	}
+}
+
+// Results returns the channel on which search results are delivered.
+// If a result is not received before the next one is ready, it is dropped.
+// The channel is closed by Close.
+func (l *LiveSearch) Results() <-chan LiveSearchResult {
+	return l.results
+}
+
+// SetQuery updates the search query. Cached results and empty queries
+// are delivered immediately; otherwise a search starts after the debounce delay.
+func (l *LiveSearch) SetQuery(query string) {
+	query = strings.TrimSpace(query)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return
+	}
+	l.seq++
+	l.stopLocked()
+
+	if query == "" {
+		l.emitLocked(LiveSearchResult{Query: query, Result: &SearchResult{}})
+		return
+	}
+	if res, ok := l.cache[query]; ok {
+		l.emitLocked(LiveSearchResult{Query: query, Result: res})
+		return
+	}
+	seq := l.seq
+	l.timer = time.AfterFunc(l.debounce, func() { l.run(seq, query) })
+}
+
+// Close stops any pending search and closes the Results channel.
+func (l *LiveSearch) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed {
+		return
+	}
+	l.closed = true
+	l.stopLocked()
+	close(l.results)
+}
+
+func (l *LiveSearch) run(seq uint64, query string) {
+	l.mu.Lock()
+	if l.closed || seq != l.seq {
+		l.mu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	l.cancel = cancel
+	l.mu.Unlock()
+
+	res, err := l.search(ctx, query)
+	cancel()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closed || seq != l.seq {
+		return
+	}
+	l.cancel = nil
+	if err == nil {
+		l.cachePutLocked(query, res)
+	}
+	l.emitLocked(LiveSearchResult{Query: query, Result: res, Err: err})
+}
+
+// stopLocked cancels the pending debounce timer and any in-flight search.
+func (l *LiveSearch) stopLocked() {
+	if l.timer != nil {
+		l.timer.Stop()
+		l.timer = nil
+	}
+	if l.cancel != nil {
+		l.cancel()
+		l.cancel = nil
+	}
+}
+
+// emitLocked delivers r, replacing any result that has not yet been received.
+func (l *LiveSearch) emitLocked(r LiveSearchResult) {
+	select {
+	case <-l.results:
+	default:
+	}
+	l.results <- r
+}
+
+func (l *LiveSearch) cachePutLocked(query string, res *SearchResult) {
+	if _, ok := l.cache[query]; !ok {
+		l.keys = append(l.keys, query)
+	}
+	l.cache[query] = res
+	if len(l.keys) > liveSearchCacheSize {
+		delete(l.cache, l.keys[0])
+		l.keys = l.keys[1:]
+	}
+}
@@ -0,0 +1,92 @@
+package jellyfin
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeSearcher struct {
+	mu      sync.Mutex
+	queries []string
+	block   map[string]bool
+}
+
+func (f *fakeSearcher) search(ctx context.Context, query string) (*SearchResult, error) {
+	f.mu.Lock()
+	f.queries = append(f.queries, query)
+	block := f.block[query]
+	f.mu.Unlock()
+	if block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &SearchResult{Songs: []*Song{{Name: query}}}, nil
+}
+
+func (f *fakeSearcher) calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string{}, f.queries...)
+}
+
+func receive(t *testing.T, l *LiveSearch) LiveSearchResult {
+	t.Helper()
+	select {
+	case r := <-l.Results():
+		return r
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for result")
+		return LiveSearchResult{}
+	}
+}
+
+func TestLiveSearch_Debounce(t *testing.T) {
+	f := &fakeSearcher{}
+	l := newLiveSearch(f.search, 20*time.Millisecond)
+	defer l.Close()
+
+	for _, q := range []string{"b", "be", "bea", "beat"} {
+		l.SetQuery(q)
+	}
+	r := receive(t, l)
+	if r.Query != "beat" || r.Err != nil || r.Result.Songs[0].Name != "beat" {
+		t.Errorf("got result %+v, want result for \"beat\"", r)
+	}
+	if calls := f.calls(); len(calls) != 1 || calls[0] != "beat" {
+		t.Errorf("got searches %v, want only [beat]", calls)
+	}
+
+	// a repeated query is served from the cache
+	l.SetQuery("beat")
+	if r := receive(t, l); r.Query != "beat" {
+		t.Errorf("got result for %q, want cached result for \"beat\"", r.Query)
+	}
+	if calls := f.calls(); len(calls) != 1 {
+		t.Errorf("got searches %v, want cached result to be reused", calls)
+	}
+}
+
+func TestLiveSearch_CancelsStale(t *testing.T) {
+	f := &fakeSearcher{block: map[string]bool{"slow": true}}
+	l := newLiveSearch(f.search, time.Millisecond)
+	defer l.Close()
+
+	l.SetQuery("slow")
+	deadline := time.Now().Add(time.Second)
+	for len(f.calls()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	l.SetQuery("fast")
+
+	r := receive(t, l)
+	if r.Query != "fast" || r.Err != nil {
+		t.Errorf("got result %+v, want result for \"fast\"", r)
+	}
+	select {
+	case r := <-l.Results():
+		t.Errorf("got unexpected result %+v", r)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
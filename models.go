@@ -1,5 +1,7 @@
 package jellyfin
 
+import "encoding/json"
+
 type ItemType string
 
 const (
@@ -8,8 +10,14 @@ const (
 	TypePlaylist ItemType = "Playlist"
 	//	TypeQueue    ItemType = "Queue"
 	//	TypeHistory  ItemType = "History"
-	TypeSong  ItemType = "Song"
-	TypeGenre ItemType = "Genre"
+	TypeSong       ItemType = "Song"
+	TypeGenre      ItemType = "Genre"
+	TypeMovie      ItemType = "Movie"
+	TypeSeries     ItemType = "Series"
+	TypeSeason     ItemType = "Season"
+	TypeEpisode    ItemType = "Episode"
+	TypeAudioBook  ItemType = "AudioBook"
+	TypeMusicVideo ItemType = "MusicVideo"
 
 	TypeCollectionFolder = "CollectionFolder"
 )
@@ -21,6 +29,7 @@ const (
 	CollectionTypePlaylists CollectionType = "playlists"
 	CollectionTypeMovies    CollectionType = "movies"
 	CollectionTypeShows     CollectionType = "shows"
+	CollectionTypeBooks     CollectionType = "books"
 	CollectionTypeUnknown   CollectionType = "unknown"
 )
 
@@ -37,14 +46,18 @@ type BaseItem struct {
 	ChildCount     int       `json:"ChildCount,omitempty"`
 	UserData       *UserData `json:"UserData,omitempty"`
 	Type           string    `json:"Type"`
+	ImageTags      Images    `json:"ImageTags"`
 }
 
 type UserData struct {
-	PlayCount      int    `json:"PlayCount"`
-	IsFavorite     bool   `json:"IsFavorite"`
-	Rating         int    `json:"Rating"`
-	Played         bool   `json:"Played"`
-	LastPlayedDate string `json:"LastPlayedDate"`
+	PlayCount             int     `json:"PlayCount"`
+	IsFavorite            bool    `json:"IsFavorite"`
+	Rating                int     `json:"Rating"`
+	Played                bool    `json:"Played"`
+	LastPlayedDate        string  `json:"LastPlayedDate"`
+	PlaybackPositionTicks int64   `json:"PlaybackPositionTicks"`
+	PlayedPercentage      float64 `json:"PlayedPercentage"`
+	UnplayedItemCount     int     `json:"UnplayedItemCount"`
 }
 
 type NameID struct {
@@ -55,9 +68,14 @@ type NameID struct {
 type Images struct {
 	Primary string `json:"Primary"`
 	Disc    string `json:"Disc"`
+	Thumb   string `json:"Thumb"`
+	Logo    string `json:"Logo"`
+	Banner  string `json:"Banner"`
 }
 
 type MediaSource struct {
+	ID           string         `json:"Id"`
+	Name         string         `json:"Name"`
 	Bitrate      int            `json:"Bitrate"`
 	Container    string         `json:"Container"`
 	Path         string         `json:"Path"`
@@ -66,31 +84,71 @@ type MediaSource struct {
 }
 
 type MediaStream struct {
+	Index         int    `json:"Index"`
+	Type          string `json:"Type"`
 	Codec         string `json:"Codec"`
+	Language      string `json:"Language"`
+	Title         string `json:"Title"`
+	DisplayTitle  string `json:"DisplayTitle"`
+	IsDefault     bool   `json:"IsDefault"`
+	IsForced      bool   `json:"IsForced"`
+	IsExternal    bool   `json:"IsExternal"`
 	SampleRate    int    `json:"SampleRate"`
 	BitRate       int    `json:"BitRate"`
 	BitDepth      int    `json:"BitDepth"`
 	ChannelLayout string `json:"ChannelLayout"`
 	Channels      int    `json:"Channels"`
+	Width         int    `json:"Width"`
+	Height        int    `json:"Height"`
+	VideoRange    string `json:"VideoRange"`
 }
 
 type Song struct {
-	Name           string         `json:"Name"`
-	Id             string         `json:"Id"`
-	PlaylistItemId string         `json:"PlaylistItemId"`
-	RunTimeTicks   int64          `json:"RunTimeTicks"`
-	ProductionYear int            `json:"ProductionYear"`
-	DateCreated    string         `json:"DateCreated"`
-	IndexNumber    int            `json:"IndexNumber"`
-	Type           string         `json:"Type"`
-	AlbumID        string         `json:"AlbumId"`
-	Album          string         `json:"Album"`
-	DiscNumber     int            `json:"ParentIndexNumber"`
-	Artists        []NameID       `json:"ArtistItems"`
-	ImageTags      Images         `json:"ImageTags"`
-	MediaSources   []MediaSource  `json:"MediaSources"`
-	MediaStreams   []*MediaStream `json:"MediaStreams,omitempty"`
-	UserData       UserData       `json:"UserData"`
+	Name           string            `json:"Name"`
+	Id             string            `json:"Id"`
+	PlaylistItemId string            `json:"PlaylistItemId"`
+	RunTimeTicks   int64             `json:"RunTimeTicks"`
+	ProductionYear int               `json:"ProductionYear"`
+	DateCreated    string            `json:"DateCreated"`
+	IndexNumber    int               `json:"IndexNumber"`
+	Type           string            `json:"Type"`
+	AlbumID        string            `json:"AlbumId"`
+	Album          string            `json:"Album"`
+	DiscNumber     int               `json:"ParentIndexNumber"`
+	Artists        []NameID          `json:"ArtistItems"`
+	AlbumArtists   []NameID          `json:"AlbumArtists"`
+	Composers      []NameID          `json:"Composers,omitempty"` // populated from People
+	Genres         []string          `json:"Genres"`
+	ImageTags      Images            `json:"ImageTags"`
+	MediaSources   []MediaSource     `json:"MediaSources"`
+	MediaStreams   []*MediaStream    `json:"MediaStreams,omitempty"`
+	UserData       UserData          `json:"UserData"`
+	ProviderIds    map[string]string `json:"ProviderIds,omitempty"`
+}
+
+// UnmarshalJSON decodes a song, extracting its composers from the People field.
+func (s *Song) UnmarshalJSON(data []byte) error {
+	type song Song
+	dto := struct {
+		*song
+		People []Person `json:"People"`
+	}{song: (*song)(s)}
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return err
+	}
+	if dto.People == nil {
+		// keep the Composers decoded from the package's own encoding
+		return nil
+	}
+	// assign a fresh slice, so decoding into a reused Song does not accumulate composers
+	var composers []NameID
+	for _, p := range dto.People {
+		if p.Type == personTypeComposer {
+			composers = append(composers, NameID{Name: p.Name, ID: p.ID})
+		}
+	}
+	s.Composers = composers
+	return nil
 }
 
 type songs struct {
@@ -150,6 +208,29 @@ type Playlist struct {
 	Tags               []string          `json:"Tags"`
 	ProviderIds        map[string]string `json:"ProviderIds"`
 	SongCount          int               `json:"ChildCount"`
+	CanDelete          bool              `json:"CanDelete"`
+
+	// OpenAccess, Shares and Ownership are populated from the playlist's sharing
+	// information (Jellyfin 10.9+) by GetPlaylist and GetPlaylistsByOwnership.
+	// Ownership is empty if it is unknown.
+	OpenAccess bool              `json:"OpenAccess"`
+	Shares     []PlaylistUser    `json:"Shares"`
+	Ownership  PlaylistOwnership `json:"Ownership,omitempty"`
+}
+
+type PlaylistOwnership string
+
+const (
+	PlaylistOwnershipAny PlaylistOwnership = ""
+	PlaylistOwnedByMe    PlaylistOwnership = "Owned"
+	PlaylistSharedWithMe PlaylistOwnership = "Shared"
+	PlaylistPublic       PlaylistOwnership = "Public"
+)
+
+// PlaylistUser is a user a playlist is shared with.
+type PlaylistUser struct {
+	UserID  string `json:"UserId"`
+	CanEdit bool   `json:"CanEdit"`
 }
 
 type playlists struct {
@@ -157,11 +238,234 @@ type playlists struct {
 	TotalPlaylists int         `json:"TotalRecordCount"`
 }
 
+type Person struct {
+	Name            string `json:"Name"`
+	ID              string `json:"Id"`
+	Role            string `json:"Role"`
+	Type            string `json:"Type"`
+	PrimaryImageTag string `json:"PrimaryImageTag"`
+}
+
+type Movie struct {
+	Name              string            `json:"Name"`
+	ID                string            `json:"Id"`
+	OriginalTitle     string            `json:"OriginalTitle"`
+	Overview          string            `json:"Overview"`
+	Taglines          []string          `json:"Taglines"`
+	RunTimeTicks      int64             `json:"RunTimeTicks"`
+	Year              int               `json:"ProductionYear"`
+	PremiereDate      string            `json:"PremiereDate"`
+	DateCreated       string            `json:"DateCreated"`
+	OfficialRating    string            `json:"OfficialRating"`
+	CommunityRating   float64           `json:"CommunityRating"`
+	CriticRating      float64           `json:"CriticRating"`
+	Genres            []string          `json:"Genres"`
+	Studios           []NameID          `json:"Studios"`
+	People            []Person          `json:"People"`
+	ProviderIds       map[string]string `json:"ProviderIds"`
+	ImageTags         Images            `json:"ImageTags"`
+	BackdropImageTags []string          `json:"BackdropImageTags"`
+	MediaSources      []MediaSource     `json:"MediaSources"`
+	MediaStreams      []*MediaStream    `json:"MediaStreams,omitempty"`
+	UserData          UserData          `json:"UserData"`
+	Type              string            `json:"Type"`
+}
+
+type movies struct {
+	Movies      []*Movie `json:"Items"`
+	TotalMovies int      `json:"TotalRecordCount"`
+}
+
+type Series struct {
+	Name              string            `json:"Name"`
+	ID                string            `json:"Id"`
+	Overview          string            `json:"Overview"`
+	Year              int               `json:"ProductionYear"`
+	PremiereDate      string            `json:"PremiereDate"`
+	EndDate           string            `json:"EndDate"`
+	Status            string            `json:"Status"`
+	DateCreated       string            `json:"DateCreated"`
+	OfficialRating    string            `json:"OfficialRating"`
+	CommunityRating   float64           `json:"CommunityRating"`
+	Genres            []string          `json:"Genres"`
+	Studios           []NameID          `json:"Studios"`
+	People            []Person          `json:"People"`
+	ProviderIds       map[string]string `json:"ProviderIds"`
+	SeasonCount       int               `json:"ChildCount"`
+	ImageTags         Images            `json:"ImageTags"`
+	BackdropImageTags []string          `json:"BackdropImageTags"`
+	UserData          UserData          `json:"UserData"`
+	Type              string            `json:"Type"`
+}
+
+type seriesList struct {
+	Series      []*Series `json:"Items"`
+	TotalSeries int       `json:"TotalRecordCount"`
+}
+
+type Season struct {
+	Name         string   `json:"Name"`
+	ID           string   `json:"Id"`
+	SeriesID     string   `json:"SeriesId"`
+	SeriesName   string   `json:"SeriesName"`
+	IndexNumber  int      `json:"IndexNumber"`
+	Overview     string   `json:"Overview"`
+	Year         int      `json:"ProductionYear"`
+	EpisodeCount int      `json:"ChildCount"`
+	ImageTags    Images   `json:"ImageTags"`
+	UserData     UserData `json:"UserData"`
+	Type         string   `json:"Type"`
+}
+
+type seasons struct {
+	Seasons      []*Season `json:"Items"`
+	TotalSeasons int       `json:"TotalRecordCount"`
+}
+
+type Episode struct {
+	Name              string         `json:"Name"`
+	ID                string         `json:"Id"`
+	SeriesID          string         `json:"SeriesId"`
+	SeriesName        string         `json:"SeriesName"`
+	SeasonID          string         `json:"SeasonId"`
+	SeasonName        string         `json:"SeasonName"`
+	IndexNumber       int            `json:"IndexNumber"`
+	IndexNumberEnd    int            `json:"IndexNumberEnd"`
+	SeasonNumber      int            `json:"ParentIndexNumber"`
+	Overview          string         `json:"Overview"`
+	RunTimeTicks      int64          `json:"RunTimeTicks"`
+	PremiereDate      string         `json:"PremiereDate"`
+	DateCreated       string         `json:"DateCreated"`
+	OfficialRating    string         `json:"OfficialRating"`
+	CommunityRating   float64        `json:"CommunityRating"`
+	ImageTags         Images         `json:"ImageTags"`
+	BackdropImageTags []string       `json:"BackdropImageTags"`
+	MediaSources      []MediaSource  `json:"MediaSources"`
+	MediaStreams      []*MediaStream `json:"MediaStreams,omitempty"`
+	UserData          UserData       `json:"UserData"`
+	Type              string         `json:"Type"`
+}
+
+type episodes struct {
+	Episodes      []*Episode `json:"Items"`
+	TotalEpisodes int        `json:"TotalRecordCount"`
+}
+
+type Chapter struct {
+	Name               string `json:"Name"`
+	StartPositionTicks int64  `json:"StartPositionTicks"`
+	ImageTag           string `json:"ImageTag"`
+}
+
+type AudioBook struct {
+	Name         string        `json:"Name"`
+	ID           string        `json:"Id"`
+	Album        string        `json:"Album"`
+	Overview     string        `json:"Overview"`
+	Authors      []NameID      `json:"ArtistItems"`
+	Year         int           `json:"ProductionYear"`
+	DateCreated  string        `json:"DateCreated"`
+	Genres       []string      `json:"Genres"`
+	RunTimeTicks int64         `json:"RunTimeTicks"`
+	IndexNumber  int           `json:"IndexNumber"`
+	Chapters     []Chapter     `json:"Chapters"`
+	ImageTags    Images        `json:"ImageTags"`
+	MediaSources []MediaSource `json:"MediaSources"`
+	UserData     UserData      `json:"UserData"`
+	Type         string        `json:"Type"`
+}
+
+type audioBooks struct {
+	AudioBooks      []*AudioBook `json:"Items"`
+	TotalAudioBooks int          `json:"TotalRecordCount"`
+}
+
+type Collection struct {
+	Name        string   `json:"Name"`
+	ID          string   `json:"Id"`
+	Overview    string   `json:"Overview"`
+	DateCreated string   `json:"DateCreated"`
+	Type        string   `json:"Type"`
+	Genres      []string `json:"Genres"`
+	ItemCount   int      `json:"ChildCount"`
+	ImageTags   Images   `json:"ImageTags"`
+	UserData    UserData `json:"UserData"`
+}
+
+type collections struct {
+	Collections      []*Collection `json:"Items"`
+	TotalCollections int           `json:"TotalRecordCount"`
+}
+
+type MusicVideo struct {
+	Name         string        `json:"Name"`
+	ID           string        `json:"Id"`
+	Artists      []NameID      `json:"ArtistItems"`
+	Album        string        `json:"Album"`
+	Overview     string        `json:"Overview"`
+	Year         int           `json:"ProductionYear"`
+	DateCreated  string        `json:"DateCreated"`
+	Genres       []string      `json:"Genres"`
+	RunTimeTicks int64         `json:"RunTimeTicks"`
+	ImageTags    Images        `json:"ImageTags"`
+	MediaSources []MediaSource `json:"MediaSources"`
+	UserData     UserData      `json:"UserData"`
+	Type         string        `json:"Type"`
+}
+
+type musicVideos struct {
+	MusicVideos      []*MusicVideo `json:"Items"`
+	TotalMusicVideos int           `json:"TotalRecordCount"`
+}
+
+type Folder struct {
+	Name        string `json:"Name"`
+	ID          string `json:"Id"`
+	Path        string `json:"Path"`
+	ParentID    string `json:"ParentId"`
+	DateCreated string `json:"DateCreated"`
+	ChildCount  int    `json:"ChildCount"`
+	ImageTags   Images `json:"ImageTags"`
+	Type        string `json:"Type"`
+}
+
+// FolderItem is an entry in a folder listing.
+// Exactly one of Folder, Album or Song is set.
+type FolderItem struct {
+	Folder *Folder
+	Album  *Album
+	Song   *Song
+}
+
 type SearchResult struct {
-	Artists   []*Artist
-	Albums    []*Album
-	Songs     []*Song
-	Playlists []*Playlist
+	Artists     []*Artist
+	Albums      []*Album
+	Songs       []*Song
+	Playlists   []*Playlist
+	MusicVideos []*MusicVideo
+
+	// Total number of matches of each type on the server,
+	// which may be more than the number of items returned.
+	TotalArtists     int
+	TotalAlbums      int
+	TotalSongs       int
+	TotalPlaylists   int
+	TotalMusicVideos int
+
+	// TopResult is the most relevant match across all types.
+	// It is only set by SearchAll.
+	TopResult *SearchTopResult
+}
+
+// SearchTopResult is the best match of a multi-type search.
+// Exactly one of Artist, Album, Song, Playlist and MusicVideo is set, according to Type.
+type SearchTopResult struct {
+	Type       ItemType
+	Artist     *Artist
+	Album      *Album
+	Song       *Song
+	Playlist   *Playlist
+	MusicVideo *MusicVideo
 }
 
 type Lyrics struct {
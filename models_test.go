@@ -0,0 +1,55 @@
+package jellyfin
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSong_UnmarshalJSON(t *testing.T) {
+	data := `{
+		"Name": "Clair de Lune",
+		"Id": "song1",
+		"ArtistItems": [{"Name": "Isao Tomita", "Id": "artist1"}],
+		"AlbumArtists": [{"Name": "Isao Tomita", "Id": "artist1"}],
+		"People": [
+			{"Name": "Claude Debussy", "Id": "person1", "Type": "Composer"},
+			{"Name": "Someone Else", "Id": "person2", "Type": "Lyricist"}
+		]
+	}`
+
+	var song Song
+	if err := json.Unmarshal([]byte(data), &song); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if song.Name != "Clair de Lune" || song.Id != "song1" {
+		t.Errorf("basic fields not decoded: %+v", song)
+	}
+	if want := []NameID{{Name: "Isao Tomita", ID: "artist1"}}; !reflect.DeepEqual(song.AlbumArtists, want) {
+		t.Errorf("got album artists %v, want %v", song.AlbumArtists, want)
+	}
+	if want := []NameID{{Name: "Claude Debussy", ID: "person1"}}; !reflect.DeepEqual(song.Composers, want) {
+		t.Errorf("got composers %v, want %v", song.Composers, want)
+	}
+
+	// composers must survive a round trip through the package's own encoding
+	enc, err := json.Marshal(&song)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Song
+	if err := json.Unmarshal(enc, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded.Composers, song.Composers) {
+		t.Errorf("after round trip got composers %v, want %v", decoded.Composers, song.Composers)
+	}
+
+	// decoding into a reused song replaces the composers
+	if err := json.Unmarshal([]byte(data), &song); err != nil {
+		t.Fatal(err)
+	}
+	if len(song.Composers) != 1 {
+		t.Errorf("got composers %v after decoding twice, want one", song.Composers)
+	}
+}
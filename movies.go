@@ -0,0 +1,127 @@
+package jellyfin
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// hdrScanPageSize is the page size used to scan movies for HDR streams.
+const hdrScanPageSize = 200
+
+var (
+	movieIncludeFields       = []string{"Genres", "DateCreated", "UserData", "Overview", "OfficialRating", "CommunityRating", "ProviderIds"}
+	movieDetailIncludeFields = append(append([]string{}, movieIncludeFields...), "Studios", "People", "Taglines", "OriginalTitle", "MediaSources", "MediaStreams", "CriticRating", "PremiereDate")
+
+	movieFields = fieldSet{
+		list:     []string{"Genres", "DateCreated", "OfficialRating", "CommunityRating"},
+		standard: movieIncludeFields,
+		detail:   movieDetailIncludeFields,
+	}
+)
+
+// GetMovies returns movies with given sort, filter, and paging options.
+// The video filters (Resolution, HDR, MaxParentalRating) of Filter are supported.
+func (c *Client) GetMovies(opts QueryOpts) ([]*Movie, error) {
+	if opts.Filter.HDR {
+		return c.getHDRMovies(opts)
+	}
+	params, err := c.movieParams(opts)
+	if err != nil {
+		return nil, err
+	}
+	params.setPaging(opts.Paging)
+	return c.getMovies(params)
+}
+
+// getHDRMovies pages through the movies matching the other filters and keeps
+// those with an HDR stream, since the server cannot filter by HDR, until the
+// requested page of HDR movies is filled.
+func (c *Client) getHDRMovies(opts QueryOpts) ([]*Movie, error) {
+	params, err := c.movieParams(opts)
+	if err != nil {
+		return nil, err
+	}
+	// the media streams are needed to detect HDR
+	params["Fields"] = appendFilter(params["Fields"], "MediaStreams", ",")
+
+	skip, limit := opts.Paging.StartIndex, opts.Paging.Limit
+	hdr := make([]*Movie, 0)
+	for start := 0; ; start += hdrScanPageSize {
+		params.setPaging(Paging{StartIndex: start, Limit: hdrScanPageSize})
+		page, err := c.getMovies(params)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range page {
+			if !m.IsHDR() {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			hdr = append(hdr, m)
+			if limit > 0 && len(hdr) == limit {
+				return hdr, nil
+			}
+		}
+		if len(page) < hdrScanPageSize {
+			return hdr, nil
+		}
+	}
+}
+
+func (c *Client) movieParams(opts QueryOpts) (params, error) {
+	params := c.defaultParams()
+	params.enableRecursive()
+	params.setSorting(opts.Sort)
+	if err := params.setFilter(mediaTypeMovie, opts.Filter); err != nil {
+		return nil, err
+	}
+	params.setIncludeTypes(mediaTypeMovie)
+	params.setResponse(opts.Response, movieFields)
+	return params, nil
+}
+
+func (c *Client) getMovies(params params) ([]*Movie, error) {
+	resp, err := c.get(fmt.Sprintf("/Users/%s/Items", c.userID), params)
+	if err != nil {
+		return nil, fmt.Errorf("get movies: %v", err)
+	}
+	defer resp.Close()
+
+	dto := movies{}
+	if err := json.NewDecoder(resp).Decode(&dto); err != nil {
+		return nil, fmt.Errorf("decode json: %v", err)
+	}
+	return dto.Movies, nil
+}
+
+// GetMovie returns the movie with full metadata, including studios, people and media sources.
+func (c *Client) GetMovie(movieID string) (*Movie, error) {
+	movie := &Movie{}
+	if err := c.getItemByID(movieID, movie, movieDetailIncludeFields...); err != nil {
+		return nil, err
+	}
+	return movie, nil
+}
+
+// IsHDR returns true if the movie's video stream is HDR.
+// Requires the movie to have been fetched with its media streams.
+func (m *Movie) IsHDR() bool {
+	for _, src := range m.MediaSources {
+		if hasHDRStream(src.MediaStreams) {
+			return true
+		}
+	}
+	return hasHDRStream(m.MediaStreams)
+}
+
+func hasHDRStream(streams []*MediaStream) bool {
+	for _, s := range streams {
+		if s.Type == "Video" && s.VideoRange == "HDR" {
+			return true
+		}
+	}
+	return false
+}
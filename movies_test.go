@@ -0,0 +1,97 @@
+package jellyfin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// movieServer serves n movies, of which every third is HDR,
+// and records the query of each request.
+func movieServer(n int, queries *[]url.Values) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		*queries = append(*queries, q)
+		start, _ := strconv.Atoi(q.Get("StartIndex"))
+		limit, _ := strconv.Atoi(q.Get("Limit"))
+		var items []map[string]any
+		for i := start; i < n && (limit == 0 || i < start+limit); i++ {
+			videoRange := "SDR"
+			if i%3 == 0 {
+				videoRange = "HDR"
+			}
+			items = append(items, map[string]any{
+				"Id":           fmt.Sprint(i),
+				"MediaStreams": []map[string]string{{"Type": "Video", "VideoRange": videoRange}},
+			})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"Items": items, "TotalRecordCount": n})
+	}
+}
+
+func TestGetMovies(t *testing.T) {
+	var queries []url.Values
+	c := newTestClient(t, movieServer(50, &queries))
+
+	movies, err := c.GetMovies(QueryOpts{
+		Paging: Paging{StartIndex: 10, Limit: 20},
+		Filter: Filter{Resolution: Resolution4K},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(movies) != 20 || movies[0].ID != "10" {
+		t.Errorf("got %d movies starting at %s, want 20 starting at 10", len(movies), movies[0].ID)
+	}
+	q := queries[0]
+	if q.Get("IncludeItemTypes") != "Movie" || q.Get("Is4K") != "true" || q.Get("StartIndex") != "10" || q.Get("Limit") != "20" {
+		t.Errorf("got query %v", q)
+	}
+	if strings.Contains(q.Get("Fields"), "MediaStreams") {
+		t.Errorf("list call requested media streams: %s", q.Get("Fields"))
+	}
+}
+
+func TestGetMovies_HDR(t *testing.T) {
+	var queries []url.Values
+	c := newTestClient(t, movieServer(2*hdrScanPageSize+50, &queries))
+
+	// HDR movies are 0, 3, 6, ...; the page spans several scanned pages
+	movies, err := c.GetMovies(QueryOpts{
+		Paging: Paging{StartIndex: 60, Limit: 40},
+		Filter: Filter{HDR: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(movies) != 40 {
+		t.Fatalf("got %d movies, want a full page of 40", len(movies))
+	}
+	for i, m := range movies {
+		if want := fmt.Sprint(3 * (60 + i)); m.ID != want {
+			t.Errorf("movie %d: got %s, want %s", i, m.ID, want)
+		}
+	}
+	if len(queries) != 2 {
+		t.Errorf("got %d requests, want 2", len(queries))
+	}
+	for _, q := range queries {
+		if !strings.Contains(q.Get("Fields"), "MediaStreams") {
+			t.Errorf("HDR scan did not request media streams: %s", q.Get("Fields"))
+		}
+	}
+
+	// the last page is short when the movies run out
+	queries = nil
+	movies, err = c.GetMovies(QueryOpts{Paging: Paging{StartIndex: 140, Limit: 40}, Filter: Filter{HDR: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(movies) != 10 || len(queries) != 3 {
+		t.Errorf("got %d movies in %d requests, want 10 in 3", len(movies), len(queries))
+	}
+}
@@ -0,0 +1,75 @@
+package jellyfin
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+var musicVideoIncludeFields = []string{"Genres", "DateCreated", "UserData", "ParentId", "MediaSources"}
+
+var musicVideoFields = fieldSet{
+	list:     []string{"Genres", "DateCreated"},
+	standard: musicVideoIncludeFields,
+	detail:   append(append([]string{}, musicVideoIncludeFields...), "Overview", "ProviderIds", "MediaStreams"),
+}
+
+// GetMusicVideos returns music videos with given sort, filter, and paging options.
+// - Can be used to get an artist's music videos with the ArtistID filter.
+func (c *Client) GetMusicVideos(opts QueryOpts) ([]*MusicVideo, error) {
+	params := c.defaultParams()
+	params.enableRecursive()
+	params.setPaging(opts.Paging)
+	params.setSorting(opts.Sort)
+	if err := params.setFilter(mediaTypeMusicVideo, opts.Filter); err != nil {
+		return nil, err
+	}
+	params.setIncludeTypes(mediaTypeMusicVideo)
+	params.setResponse(opts.Response, musicVideoFields)
+	resp, err := c.get(fmt.Sprintf("/Users/%s/Items", c.userID), params)
+	if err != nil {
+		return nil, fmt.Errorf("get music videos: %v", err)
+	}
+	defer resp.Close()
+
+	dto := musicVideos{}
+	if err := json.NewDecoder(resp).Decode(&dto); err != nil {
+		return nil, fmt.Errorf("decode json: %v", err)
+	}
+	return dto.MusicVideos, nil
+}
+
+func (c *Client) GetMusicVideo(id string) (*MusicVideo, error) {
+	video := &MusicVideo{}
+	includeFields := append(musicVideoIncludeFields, "Overview")
+	if err := c.getItemByID(id, video, includeFields...); err != nil {
+		return nil, err
+	}
+	return video, nil
+}
+
+// GetArtistWithMusicVideos fetches an artist along with their music videos,
+// sorted by year, so that they can be presented together on one page.
+func (c *Client) GetArtistWithMusicVideos(artistID string) (*Artist, []*MusicVideo, error) {
+	var wg sync.WaitGroup
+	var videos []*MusicVideo
+	var videosErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		videos, videosErr = c.GetMusicVideos(QueryOpts{
+			Filter: Filter{ArtistID: artistID},
+			Sort:   Sort{Field: SortByYear, Mode: SortAsc},
+		})
+	}()
+
+	artist, err := c.GetArtist(artistID)
+	wg.Wait()
+	if err != nil {
+		return nil, nil, err
+	}
+	if videosErr != nil {
+		return nil, nil, videosErr
+	}
+	return artist, videos, nil
+}
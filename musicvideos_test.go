@@ -0,0 +1,43 @@
+package jellyfin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+func TestGetArtistWithMusicVideos(t *testing.T) {
+	var mu sync.Mutex
+	var videoQuery url.Values
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/Users/user/Items/artist":
+			json.NewEncoder(w).Encode(map[string]string{"Id": "artist", "Name": "Artist"})
+		case "/Users/user/Items":
+			mu.Lock()
+			videoQuery = r.URL.Query()
+			mu.Unlock()
+			json.NewEncoder(w).Encode(map[string]any{"Items": []map[string]string{{"Id": "v1"}, {"Id": "v2"}}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	artist, videos, err := c.GetArtistWithMusicVideos("artist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if artist.Name != "Artist" || len(videos) != 2 || videos[0].ID != "v1" {
+		t.Errorf("got artist %+v and videos %+v", artist, videos)
+	}
+	q := videoQuery
+	if q.Get("IncludeItemTypes") != "MusicVideo" || q.Get("ArtistIds") != "artist" || q.Get("SortBy") != string(SortByYear) || q.Get("Recursive") != "true" {
+		t.Errorf("got music video query %v", q)
+	}
+
+	if _, _, err := c.GetArtistWithMusicVideos("unknown"); err == nil {
+		t.Error("expected error for unknown artist")
+	}
+}
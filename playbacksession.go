@@ -0,0 +1,380 @@
+package jellyfin
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const defaultReportInterval = 10 * time.Second
+
+type PlayMethod string
+
+const (
+	PlayMethodDirectPlay   PlayMethod = "DirectPlay"
+	PlayMethodDirectStream PlayMethod = "DirectStream"
+	PlayMethodTranscode    PlayMethod = "Transcode"
+)
+
+type RepeatMode string
+
+const (
+	RepeatNone RepeatMode = "RepeatNone"
+	RepeatAll  RepeatMode = "RepeatAll"
+	RepeatOne  RepeatMode = "RepeatOne"
+)
+
+// PlaybackSessionOptions configures a PlaybackSession.
+type PlaybackSessionOptions struct {
+	// MediaSourceID of the media source being played.
+	// If empty, the item ID is used, which is the default source for audio items.
+	MediaSourceID string
+
+	// Transcoding options for the stream. If nil, the file is played directly.
+	Transcode *TranscodeOptions
+
+	// Queue contains the IDs of the items in the current play queue.
+	Queue []string
+
+	// Position returns the current playback position. It is called
+	// on every progress report and when the session is stopped.
+	Position func() time.Duration
+
+	// ReportInterval is how often progress is reported while playing.
+	// If 0, a default of 10 seconds is used.
+	ReportInterval time.Duration
+
+	// OnError, if set, is called with errors from background progress reports.
+	OnError func(error)
+}
+
+// PlaybackSession reports playback of a single item to the server.
+// It owns the play session ID used in the stream URL so that the
+// server can associate the stream with the reported progress.
+type PlaybackSession struct {
+	client        *Client
+	itemID        string
+	playSessionID string
+	mediaSourceID string
+	playMethod    PlayMethod
+	opts          PlaybackSessionOptions
+
+	mu         sync.Mutex
+	started    bool
+	stopped    bool
+	paused     bool
+	muted      bool
+	volume     int
+	repeatMode RepeatMode
+	shuffle    bool
+	queue      []string
+	done       chan struct{}
+
+	// starting is closed when an in-flight start report has finished.
+	// Updates made meanwhile are reported after it as deferredEvent.
+	starting      chan struct{}
+	deferredEvent string
+}
+
+type queueItem struct {
+	Id string `json:"Id"`
+}
+
+type playbackInfoBody struct {
+	ItemId          string      `json:"ItemId"`
+	MediaSourceId   string      `json:"MediaSourceId"`
+	PlaySessionId   string      `json:"PlaySessionId"`
+	PositionTicks   int64       `json:"PositionTicks"`
+	IsPaused        bool        `json:"IsPaused"`
+	IsMuted         bool        `json:"IsMuted"`
+	VolumeLevel     int         `json:"VolumeLevel"`
+	CanSeek         bool        `json:"CanSeek"`
+	PlayMethod      PlayMethod  `json:"PlayMethod"`
+	RepeatMode      RepeatMode  `json:"RepeatMode"`
+	ShuffleMode     string      `json:"ShuffleMode"`
+	NowPlayingQueue []queueItem `json:"NowPlayingQueue,omitempty"`
+	EventName       string      `json:"EventName,omitempty"`
+}
+
+type playbackStopBody struct {
+	ItemId          string      `json:"ItemId"`
+	MediaSourceId   string      `json:"MediaSourceId"`
+	PlaySessionId   string      `json:"PlaySessionId"`
+	PositionTicks   int64       `json:"PositionTicks"`
+	NowPlayingQueue []queueItem `json:"NowPlayingQueue,omitempty"`
+}
+
+// NewPlaybackSession creates a session for playing the given item.
+// Playback is not reported until Start is called.
+func (c *Client) NewPlaybackSession(itemID string, opts PlaybackSessionOptions) *PlaybackSession {
+	method := PlayMethodDirectPlay
+	if opts.Transcode != nil {
+		method = PlayMethodTranscode
+	}
+	mediaSourceID := opts.MediaSourceID
+	if mediaSourceID == "" {
+		mediaSourceID = itemID
+	}
+	if opts.ReportInterval <= 0 {
+		opts.ReportInterval = defaultReportInterval
+	}
+	return &PlaybackSession{
+		client:        c,
+		itemID:        itemID,
+		playSessionID: randomKey(32),
+		mediaSourceID: mediaSourceID,
+		playMethod:    method,
+		opts:          opts,
+		volume:        100,
+		repeatMode:    RepeatNone,
+		queue:         opts.Queue,
+	}
+}
+
+// PlaySessionID returns the ID identifying this session to the server.
+func (s *PlaybackSession) PlaySessionID() string {
+	return s.playSessionID
+}
+
+// StreamURL returns the URL to stream the item for this session.
+func (s *PlaybackSession) StreamURL() (string, error) {
+	return s.client.audioStreamURL(s.itemID, s.playSessionID, s.mediaSourceID, s.opts.Transcode)
+}
+
+// Start reports the start of playback and, once the server has accepted it,
+// begins periodic progress reporting. Changes made while the start report is
+// in flight are reported after it. If the report fails, Start may be retried.
+func (s *PlaybackSession) Start() error {
+	pos := s.position()
+	s.mu.Lock()
+	if s.started || s.stopped || s.starting != nil {
+		s.mu.Unlock()
+		return errors.New("playback session already started")
+	}
+	s.starting = make(chan struct{})
+	body := s.infoBodyLocked("", pos)
+	s.mu.Unlock()
+
+	resp, err := s.client.post("/Sessions/Playing", s.client.defaultParams(), body)
+	if err != nil {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.finishStartLocked()
+		return fmt.Errorf("report playback start: %w", err)
+	}
+	resp.Close()
+
+	// Stop waits for starting to be closed, so the
+	// deferred updates are reported before the stop
+	for {
+		pos = s.position()
+		s.mu.Lock()
+		event := s.deferredEvent
+		if event == "" {
+			break
+		}
+		s.deferredEvent = ""
+		body = s.infoBodyLocked(event, pos)
+		s.mu.Unlock()
+		if err := s.reportProgress(body); err != nil && s.opts.OnError != nil {
+			s.opts.OnError(err)
+		}
+	}
+	defer s.mu.Unlock()
+	s.started = true
+	s.finishStartLocked()
+	s.done = make(chan struct{})
+	go s.reportLoop(s.done)
+	return nil
+}
+
+// finishStartLocked ends the in-flight start report.
+func (s *PlaybackSession) finishStartLocked() {
+	close(s.starting)
+	s.starting = nil
+	s.deferredEvent = ""
+}
+
+// Pause reports that playback has been paused.
+func (s *PlaybackSession) Pause() error {
+	return s.update(func() { s.paused = true }, string(Pause))
+}
+
+// Unpause reports that playback has resumed.
+func (s *PlaybackSession) Unpause() error {
+	return s.update(func() { s.paused = false }, string(Unpause))
+}
+
+// Seek reports that the playback position has changed.
+func (s *PlaybackSession) Seek() error {
+	return s.update(func() {}, string(TimeUpdate))
+}
+
+// SetVolume reports a change of volume (0-100) or mute state.
+func (s *PlaybackSession) SetVolume(volume int, muted bool) error {
+	return s.update(func() {
+		s.volume = volume
+		s.muted = muted
+	}, "VolumeChange")
+}
+
+// SetRepeatMode reports a change of the repeat mode.
+func (s *PlaybackSession) SetRepeatMode(mode RepeatMode) error {
+	return s.update(func() { s.repeatMode = mode }, "RepeatModeChange")
+}
+
+// SetShuffle reports a change of the shuffle mode.
+func (s *PlaybackSession) SetShuffle(shuffle bool) error {
+	return s.update(func() { s.shuffle = shuffle }, "ShuffleModeChange")
+}
+
+// SetQueue reports a change of the play queue.
+func (s *PlaybackSession) SetQueue(itemIDs []string) error {
+	return s.update(func() { s.queue = itemIDs }, "PlaylistItemMove")
+}
+
+// Stop reports the end of playback with the final position and stops
+// periodic reporting. If a start report is in flight, Stop waits for it first.
+// The stop report is sent even if Start was never called or failed.
+// Calling Stop more than once has no effect.
+func (s *PlaybackSession) Stop() error {
+	var pos time.Duration
+	for {
+		pos = s.position()
+		s.mu.Lock()
+		if s.starting == nil {
+			break
+		}
+		starting := s.starting
+		s.mu.Unlock()
+		<-starting
+	}
+	if s.stopped {
+		s.mu.Unlock()
+		return nil
+	}
+	s.stopped = true
+	if s.done != nil {
+		close(s.done)
+	}
+	body := playbackStopBody{
+		ItemId:          s.itemID,
+		MediaSourceId:   s.mediaSourceID,
+		PlaySessionId:   s.playSessionID,
+		PositionTicks:   durationToTicks(pos),
+		NowPlayingQueue: s.queueLocked(),
+	}
+	s.mu.Unlock()
+
+	resp, err := s.client.post("/Sessions/Playing/Stopped", s.client.defaultParams(), body)
+	if err != nil {
+		return fmt.Errorf("report playback stopped: %w", err)
+	}
+	resp.Close()
+	return nil
+}
+
+// update applies a state change and, if the session is active, reports it.
+// While the start report is in flight, the report is deferred until after it.
+func (s *PlaybackSession) update(change func(), event string) error {
+	pos := s.position()
+	s.mu.Lock()
+	change()
+	if s.starting != nil {
+		s.deferredEvent = event
+		s.mu.Unlock()
+		return nil
+	}
+	if !s.started || s.stopped {
+		s.mu.Unlock()
+		return nil
+	}
+	body := s.infoBodyLocked(event, pos)
+	s.mu.Unlock()
+	return s.reportProgress(body)
+}
+
+func (s *PlaybackSession) reportLoop(done chan struct{}) {
+	ticker := time.NewTicker(s.opts.ReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			pos := s.position()
+			s.mu.Lock()
+			if s.stopped {
+				s.mu.Unlock()
+				return
+			}
+			body := s.infoBodyLocked(string(TimeUpdate), pos)
+			s.mu.Unlock()
+			if err := s.reportProgress(body); err != nil && s.opts.OnError != nil {
+				s.opts.OnError(err)
+			}
+		}
+	}
+}
+
+func (s *PlaybackSession) reportProgress(body playbackInfoBody) error {
+	resp, err := s.client.post("/Sessions/Playing/Progress", s.client.defaultParams(), body)
+	if err != nil {
+		return fmt.Errorf("report playback progress: %w", err)
+	}
+	resp.Close()
+	return nil
+}
+
+func (s *PlaybackSession) infoBodyLocked(event string, pos time.Duration) playbackInfoBody {
+	shuffleMode := "Sorted"
+	if s.shuffle {
+		shuffleMode = "Shuffle"
+	}
+	return playbackInfoBody{
+		ItemId:          s.itemID,
+		MediaSourceId:   s.mediaSourceID,
+		PlaySessionId:   s.playSessionID,
+		PositionTicks:   durationToTicks(pos),
+		IsPaused:        s.paused,
+		IsMuted:         s.muted,
+		VolumeLevel:     s.volume,
+		CanSeek:         s.opts.Transcode == nil,
+		PlayMethod:      s.playMethod,
+		RepeatMode:      s.repeatMode,
+		ShuffleMode:     shuffleMode,
+		NowPlayingQueue: s.queueLocked(),
+		EventName:       event,
+	}
+}
+
+// position returns the current playback position. It must be called without
+// holding mu, since the Position callback may call back into the session.
+func (s *PlaybackSession) position() time.Duration {
+	if s.opts.Position == nil {
+		return 0
+	}
+	return s.opts.Position()
+}
+
+func (s *PlaybackSession) queueLocked() []queueItem {
+	if len(s.queue) == 0 {
+		return nil
+	}
+	q := make([]queueItem, len(s.queue))
+	for i, id := range s.queue {
+		q[i] = queueItem{Id: id}
+	}
+	return q
+}
+
+// durationToTicks converts d to Jellyfin ticks (100ns units).
+func durationToTicks(d time.Duration) int64 {
+	return int64(d / 100)
+}
+
+// ticksToDuration converts Jellyfin ticks (100ns units) to a time.Duration.
+func ticksToDuration(ticks int64) time.Duration {
+	return time.Duration(ticks * 100)
+}
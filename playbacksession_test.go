@@ -0,0 +1,160 @@
+package jellyfin
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type sessionReport struct {
+	Path string
+	Body playbackInfoBody
+}
+
+// sessionRecorder records the playback reports it receives. Reports to the
+// paths in failing are answered with a server error.
+type sessionRecorder struct {
+	mu      sync.Mutex
+	reports []sessionReport
+	failing map[string]bool
+}
+
+func (r *sessionRecorder) handle(w http.ResponseWriter, req *http.Request) {
+	var body playbackInfoBody
+	json.NewDecoder(req.Body).Decode(&body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reports = append(r.reports, sessionReport{Path: req.URL.Path, Body: body})
+	if r.failing[req.URL.Path] {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (r *sessionRecorder) paths() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	paths := make([]string, len(r.reports))
+	for i, rep := range r.reports {
+		paths[i] = rep.Path
+	}
+	return paths
+}
+
+func TestPlaybackSession(t *testing.T) {
+	rec := &sessionRecorder{}
+	c := newTestClient(t, rec.handle)
+
+	session := c.NewPlaybackSession("song", PlaybackSessionOptions{
+		Position:       func() time.Duration { return 90 * time.Second },
+		ReportInterval: 10 * time.Millisecond,
+	})
+	if err := session.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if err := session.Pause(); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if err := session.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	stopped := len(rec.paths())
+	time.Sleep(30 * time.Millisecond)
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if len(rec.reports) != stopped {
+		t.Errorf("got %d reports after stopping", len(rec.reports)-stopped)
+	}
+	if len(rec.reports) < 4 {
+		t.Fatalf("got reports %v, want start, pause, progress and stop", rec.reports)
+	}
+	first, pause, last := rec.reports[0], rec.reports[1], rec.reports[len(rec.reports)-1]
+	if first.Path != "/Sessions/Playing" || last.Path != "/Sessions/Playing/Stopped" {
+		t.Errorf("got first report %s and last %s, want start and stop", first.Path, last.Path)
+	}
+	if pause.Path != "/Sessions/Playing/Progress" || pause.Body.EventName != string(Pause) || !pause.Body.IsPaused {
+		t.Errorf("got pause report %+v", pause)
+	}
+	for _, rep := range rec.reports[2 : len(rec.reports)-1] {
+		if rep.Path != "/Sessions/Playing/Progress" || rep.Body.EventName != string(TimeUpdate) {
+			t.Errorf("got report %+v between pause and stop, want progress", rep)
+		}
+	}
+	for _, rep := range rec.reports {
+		if rep.Body.PlaySessionId != session.PlaySessionID() || rep.Body.PositionTicks != durationToTicks(90*time.Second) {
+			t.Errorf("got report %+v, want session ID %s at 90s", rep, session.PlaySessionID())
+		}
+	}
+}
+
+func TestPlaybackSession_StartFails(t *testing.T) {
+	rec := &sessionRecorder{failing: map[string]bool{"/Sessions/Playing": true}}
+	c := newTestClient(t, rec.handle)
+
+	session := c.NewPlaybackSession("song", PlaybackSessionOptions{ReportInterval: 10 * time.Millisecond})
+	if err := session.Start(); err == nil {
+		t.Fatal("expected error from failed start")
+	}
+	time.Sleep(50 * time.Millisecond)
+	if got := rec.paths(); len(got) != 1 {
+		t.Errorf("got reports %v after failed start, want no progress", got)
+	}
+
+	// a failed start can be retried
+	rec.mu.Lock()
+	rec.failing = nil
+	rec.mu.Unlock()
+	if err := session.Start(); err != nil {
+		t.Fatal(err)
+	}
+	session.Stop()
+}
+
+func TestPlaybackSession_UpdatesDuringStart(t *testing.T) {
+	rec := &sessionRecorder{}
+	received := make(chan struct{})
+	release := make(chan struct{})
+	c := newTestClient(t, func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/Sessions/Playing" {
+			close(received)
+			<-release
+		}
+		rec.handle(w, req)
+	})
+
+	session := c.NewPlaybackSession("song", PlaybackSessionOptions{ReportInterval: time.Hour})
+	started := make(chan error, 1)
+	go func() { started <- session.Start() }()
+	<-received
+
+	if err := session.Pause(); err != nil {
+		t.Fatal(err)
+	}
+	stopped := make(chan error, 1)
+	go func() { stopped <- session.Stop() }()
+	time.Sleep(30 * time.Millisecond)
+	if got := rec.paths(); len(got) != 0 {
+		t.Fatalf("got reports %v before the start report finished", got)
+	}
+
+	close(release)
+	if err := <-started; err != nil {
+		t.Fatal(err)
+	}
+	if err := <-stopped; err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/Sessions/Playing", "/Sessions/Playing/Progress", "/Sessions/Playing/Stopped"}
+	if got := rec.paths(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got reports %v, want %v", got, want)
+	}
+	if pause := rec.reports[1].Body; pause.EventName != string(Pause) || !pause.IsPaused {
+		t.Errorf("got deferred report %+v, want the pause", pause)
+	}
+}
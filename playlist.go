@@ -1,9 +1,9 @@
 package jellyfin
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"strings"
 )
 
@@ -19,7 +19,8 @@ type createPlaylistResponse struct {
 	ID string `json:"Id"`
 }
 
-func (c *Client) CreatePlaylist(name, description string, public bool, trackIDs []string) error {
+// CreatePlaylist creates a new audio playlist with the given songs and returns it.
+func (c *Client) CreatePlaylist(name, description string, public bool, trackIDs []string) (*Playlist, error) {
 	body := createPlaylistBody{
 		Name:      name,
 		IsPublic:  public,
@@ -29,32 +30,35 @@ func (c *Client) CreatePlaylist(name, description string, public bool, trackIDs
 	}
 	resp, err := c.post("/Playlists", c.defaultParams(), body)
 	if err != nil {
-		return fmt.Errorf("create playlist: %v", err)
+		return nil, fmt.Errorf("create playlist: %v", err)
 	}
 	defer resp.Close()
 
+	var cpResp createPlaylistResponse
+	if err := json.NewDecoder(resp).Decode(&cpResp); err != nil {
+		return nil, fmt.Errorf("create playlist: decode response: %v", err)
+	}
+
 	// Jellyfin does not accept a description (Overview) in the CreatePlaylist call,
 	// so we need to add the description in a second request
 	if description != "" {
-		respBytes, err := io.ReadAll(resp)
-		if err != nil {
-			return err
-		}
-		var cpResp createPlaylistResponse
-		if err := json.Unmarshal(respBytes, &cpResp); err != nil {
-			return err
+		if err := c.UpdatePlaylistMetadata(cpResp.ID, name, description, public); err != nil {
+			return nil, err
 		}
-		return c.UpdatePlaylistMetadata(cpResp.ID, name, description, public)
 	}
 
-	return nil
+	return c.GetPlaylist(cpResp.ID)
 }
 
 func (c *Client) GetPlaylistSongs(playlistID string) ([]*Song, error) {
+	return c.getPlaylistSongs(context.Background(), playlistID, songIncludeFields...)
+}
+
+func (c *Client) getPlaylistSongs(ctx context.Context, playlistID string, includeFields ...string) ([]*Song, error) {
 	params := c.defaultParams()
-	params.setIncludeFields(songIncludeFields...)
+	params.setIncludeFields(includeFields...)
 
-	resp, err := c.get(fmt.Sprintf("/Playlists/%s/Items", playlistID), params)
+	resp, err := c.getContext(ctx, fmt.Sprintf("/Playlists/%s/Items", playlistID), params)
 	if err != nil {
 		return nil, fmt.Errorf("get playlist songs: %v", err)
 	}
@@ -100,9 +104,13 @@ func (c *Client) UpdatePlaylistMetadata(playlistID, name, overview string, publi
 }
 
 func (c *Client) AddSongsToPlaylist(playlistID string, trackIDs []string) error {
+	return c.addSongsToPlaylist(context.Background(), playlistID, trackIDs)
+}
+
+func (c *Client) addSongsToPlaylist(ctx context.Context, playlistID string, trackIDs []string) error {
 	params := c.defaultParams()
 	params["ids"] = strings.Join(trackIDs, ",")
-	resp, err := c.post(fmt.Sprintf("/Playlists/%s/Items", playlistID), params, struct{}{})
+	resp, err := c.postContext(ctx, fmt.Sprintf("/Playlists/%s/Items", playlistID), params, struct{}{})
 	if err != nil {
 		return fmt.Errorf("add songs to playlist: %v", err)
 	}
@@ -121,10 +129,13 @@ func (c *Client) RemoveSongsFromPlaylist(playlistID string, removeIndexes []int)
 			removeItemIds = append(removeItemIds, songs[idx].PlaylistItemId)
 		}
 	}
+	return c.removePlaylistEntries(context.Background(), playlistID, removeItemIds)
+}
 
+func (c *Client) removePlaylistEntries(ctx context.Context, playlistID string, entryIDs []string) error {
 	params := c.defaultParams()
-	params["entryIds"] = strings.Join(removeItemIds, ",")
-	resp, err := c.delete(fmt.Sprintf("/Playlists/%s/Items", playlistID), params)
+	params["entryIds"] = strings.Join(entryIDs, ",")
+	resp, err := c.deleteContext(ctx, fmt.Sprintf("/Playlists/%s/Items", playlistID), params)
 	if err != nil {
 		return fmt.Errorf("remove songs from playlist: %v", err)
 	}
@@ -133,8 +144,12 @@ func (c *Client) RemoveSongsFromPlaylist(playlistID string, removeIndexes []int)
 }
 
 func (c *Client) MovePlaylistSong(playlistID string, trackID string, newIdx int) error {
-	endpoint := fmt.Sprintf("/Playlists/%s/Items/%s/Move/%d", playlistID, trackID, newIdx)
-	resp, err := c.post(endpoint, c.defaultParams(), struct{}{})
+	return c.movePlaylistEntry(context.Background(), playlistID, trackID, newIdx)
+}
+
+func (c *Client) movePlaylistEntry(ctx context.Context, playlistID string, entryID string, newIdx int) error {
+	endpoint := fmt.Sprintf("/Playlists/%s/Items/%s/Move/%d", playlistID, entryID, newIdx)
+	resp, err := c.postContext(ctx, endpoint, c.defaultParams(), struct{}{})
 	if err != nil {
 		return fmt.Errorf("move playlist song: %v", err)
 	}
@@ -0,0 +1,597 @@
+package jellyfin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type PlaylistFormat string
+
+const (
+	PlaylistFormatM3U8 PlaylistFormat = "m3u8"
+	PlaylistFormatXSPF PlaylistFormat = "xspf"
+	PlaylistFormatJSON PlaylistFormat = "json"
+)
+
+const (
+	providerMusicBrainzTrack     = "MusicBrainzTrack"
+	providerMusicBrainzRecording = "MusicBrainzRecording"
+)
+
+var playlistExportFields = append(append([]string{}, songIncludeFields...), "Path", "ProviderIds")
+
+// PlaylistEntry is a single track of an exported or imported playlist.
+type PlaylistEntry struct {
+	// ID is the Jellyfin item ID, if known.
+	ID       string
+	Title    string
+	Artist   string
+	Album    string
+	Duration time.Duration
+	// Location is the file path or URL of the track.
+	Location string
+	// ProviderIDs contains external IDs of the track, e.g. MusicBrainz IDs.
+	ProviderIDs map[string]string
+}
+
+type exportOptions struct {
+	streamURLs bool
+	transcode  *TranscodeOptions
+}
+
+// ExportOptionFunc can be used to customize a playlist export.
+type ExportOptionFunc func(*exportOptions)
+
+// WithStreamURLs exports stream URLs instead of server file paths as track locations.
+// Note that the URLs embed the client's access token.
+func WithStreamURLs(transcodeOptions *TranscodeOptions) ExportOptionFunc {
+	return func(o *exportOptions) {
+		o.streamURLs = true
+		o.transcode = transcodeOptions
+	}
+}
+
+// ExportPlaylist writes the playlist to w in the given format.
+func (c *Client) ExportPlaylist(ctx context.Context, playlistID string, format PlaylistFormat, w io.Writer, opts ...ExportOptionFunc) error {
+	var o exportOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	pl, err := c.GetPlaylist(playlistID)
+	if err != nil {
+		return fmt.Errorf("export playlist: %w", err)
+	}
+	songs, err := c.getPlaylistSongs(ctx, playlistID, playlistExportFields...)
+	if err != nil {
+		return fmt.Errorf("export playlist: %w", err)
+	}
+
+	entries := make([]PlaylistEntry, 0, len(songs))
+	for _, s := range songs {
+		e := songToPlaylistEntry(s)
+		if o.streamURLs {
+			if e.Location, err = c.GetStreamURL(s.Id, o.transcode); err != nil {
+				return fmt.Errorf("export playlist: %w", err)
+			}
+		}
+		entries = append(entries, e)
+	}
+	return WritePlaylist(w, format, pl.Name, entries)
+}
+
+// WritePlaylist encodes the entries as a playlist in the given format.
+func WritePlaylist(w io.Writer, format PlaylistFormat, name string, entries []PlaylistEntry) error {
+	switch format {
+	case PlaylistFormatM3U8:
+		return writeM3U8(w, name, entries)
+	case PlaylistFormatXSPF:
+		return writeXSPF(w, name, entries)
+	case PlaylistFormatJSON:
+		return writePlaylistJSON(w, name, entries)
+	default:
+		return fmt.Errorf("unsupported playlist format: %s", format)
+	}
+}
+
+// ParsePlaylist decodes a playlist in the given format, returning its name (if any) and entries.
+func ParsePlaylist(r io.Reader, format PlaylistFormat) (string, []PlaylistEntry, error) {
+	switch format {
+	case PlaylistFormatM3U8:
+		return parseM3U8(r)
+	case PlaylistFormatXSPF:
+		return parseXSPF(r)
+	case PlaylistFormatJSON:
+		return parsePlaylistJSON(r)
+	default:
+		return "", nil, fmt.Errorf("unsupported playlist format: %s", format)
+	}
+}
+
+// ImportMatch is a playlist entry successfully matched to a library song.
+type ImportMatch struct {
+	Entry PlaylistEntry
+	Song  *Song
+}
+
+// ErrNoPlaylistMatches is returned by ImportPlaylist when none of the
+// entries of the playlist matched a song in the library.
+var ErrNoPlaylistMatches = errors.New("no playlist entries matched")
+
+// ImportResult describes the outcome of ImportPlaylist.
+type ImportResult struct {
+	// Playlist is the created playlist, or nil if none was created.
+	Playlist  *Playlist
+	Matched   []ImportMatch
+	Unmatched []PlaylistEntry
+}
+
+// ImportPlaylist parses a playlist in the given format, matches its entries to songs
+// in the library and creates a new playlist of the matched songs. If name is empty,
+// the name stored in the playlist file is used. Entries are matched by Jellyfin ID,
+// provider ID (e.g. MusicBrainz), file path, or fuzzy artist/title/duration comparison.
+// If no entry matched, no playlist is created, and the result is returned together
+// with ErrNoPlaylistMatches.
+func (c *Client) ImportPlaylist(ctx context.Context, r io.Reader, format PlaylistFormat, name string, public bool) (*ImportResult, error) {
+	parsedName, entries, err := ParsePlaylist(r, format)
+	if err != nil {
+		return nil, fmt.Errorf("import playlist: %w", err)
+	}
+	if name == "" {
+		name = parsedName
+	}
+	if name == "" {
+		return nil, errors.New("import playlist: no playlist name given")
+	}
+
+	result := &ImportResult{}
+	ids := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		song, err := c.matchPlaylistEntry(ctx, e)
+		if err != nil {
+			return nil, fmt.Errorf("import playlist: %w", err)
+		}
+		if song == nil {
+			result.Unmatched = append(result.Unmatched, e)
+			continue
+		}
+		result.Matched = append(result.Matched, ImportMatch{Entry: e, Song: song})
+		ids = append(ids, song.Id)
+	}
+	if len(ids) == 0 {
+		return result, fmt.Errorf("import playlist: %w", ErrNoPlaylistMatches)
+	}
+
+	pl, err := c.CreatePlaylist(name, "", public, ids)
+	if err != nil {
+		return result, fmt.Errorf("import playlist: %w", err)
+	}
+	result.Playlist = pl
+	return result, nil
+}
+
+func (c *Client) matchPlaylistEntry(ctx context.Context, e PlaylistEntry) (*Song, error) {
+	id := e.ID
+	if id == "" {
+		id = streamURLItemID(e.Location)
+	}
+	if id != "" {
+		song := &Song{}
+		err := c.getItemByIDContext(ctx, id, song, playlistExportFields...)
+		if err == nil && song.Id != "" && song.Type == string(mediaTypeAudio) {
+			return song, nil
+		}
+		// IDs from other servers are not found, or rejected if they are not GUIDs
+		if code := responseStatus(err); err != nil && code != http.StatusNotFound && code != http.StatusBadRequest {
+			return nil, err
+		}
+	}
+
+	byProvider, err := c.songsByProviderIDsContext(ctx, e.ProviderIDs)
+	if err != nil {
+		return nil, err
+	}
+	// servers that do not support the provider ID filter return unfiltered songs
+	for _, s := range byProvider {
+		if providerIDsMatch(e.ProviderIDs, s.ProviderIds) {
+			return s, nil
+		}
+	}
+
+	term := e.Title
+	if term == "" {
+		term = strings.TrimSuffix(path.Base(filepathToSlash(e.Location)), path.Ext(e.Location))
+	}
+	if term == "" {
+		return nil, nil
+	}
+	candidates, err := c.searchSongsContext(ctx, term, 50, playlistExportFields...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range candidates {
+		if providerIDsMatch(e.ProviderIDs, s.ProviderIds) {
+			return s, nil
+		}
+	}
+	if e.Location != "" {
+		for _, s := range candidates {
+			if p := songPath(s); p != "" && filepathToSlash(p) == filepathToSlash(e.Location) {
+				return s, nil
+			}
+		}
+	}
+
+	var best *Song
+	bestScore := 0.0
+	for _, s := range candidates {
+		if score := fuzzyEntryScore(e, s); score > bestScore {
+			best, bestScore = s, score
+		}
+	}
+	if bestScore >= 0.8 {
+		return best, nil
+	}
+	return nil, nil
+}
+
+func (c *Client) searchSongsContext(ctx context.Context, term string, limit int, includeFields ...string) ([]*Song, error) {
+	params := c.defaultParams()
+	params.enableRecursive()
+	params.setIncludeTypes(mediaTypeAudio)
+	params.setIncludeFields(includeFields...)
+	params.setLimit(limit)
+	params["SearchTerm"] = term
+	resp, err := c.getContext(ctx, fmt.Sprintf("/Users/%s/Items", c.userID), params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	return c.parseSongs(resp)
+}
+
+// songsByProviderIDsContext returns the songs having any of the MusicBrainz
+// track or recording IDs in providerIDs.
+func (c *Client) songsByProviderIDsContext(ctx context.Context, providerIDs map[string]string) ([]*Song, error) {
+	var ids []string
+	for _, key := range []string{providerMusicBrainzTrack, providerMusicBrainzRecording} {
+		if id := providerIDs[key]; id != "" {
+			ids = append(ids, key+"."+id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	params := c.defaultParams()
+	params.enableRecursive()
+	params.setIncludeTypes(mediaTypeAudio)
+	params.setIncludeFields(playlistExportFields...)
+	params.setLimit(10)
+	params.setList("AnyProviderIdEquals", ids, ",")
+	resp, err := c.getContext(ctx, fmt.Sprintf("/Users/%s/Items", c.userID), params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+	return c.parseSongs(resp)
+}
+
+func providerIDsMatch(a, b map[string]string) bool {
+	for _, key := range []string{providerMusicBrainzTrack, providerMusicBrainzRecording} {
+		if a[key] != "" && strings.EqualFold(a[key], b[key]) {
+			return true
+		}
+	}
+	return false
+}
+
+// fuzzyEntryScore rates how well s matches e, from 0 (no match) to 1.
+func fuzzyEntryScore(e PlaylistEntry, s *Song) float64 {
+	title := stringSimilarity(normalizeText(e.Title), normalizeText(s.Name))
+	if title < 0.7 {
+		return 0
+	}
+	score := title
+
+	if e.Artist != "" {
+		artist := 0.0
+		for _, a := range s.Artists {
+			if sim := stringSimilarity(normalizeText(e.Artist), normalizeText(a.Name)); sim > artist {
+				artist = sim
+			}
+		}
+		// artists in playlist files are often joined, e.g. "A feat. B"
+		if joined := stringSimilarity(normalizeText(e.Artist), normalizeText(songArtistNames(s))); joined > artist {
+			artist = joined
+		}
+		score = (score + artist) / 2
+	}
+
+	if e.Duration > 0 && s.RunTimeTicks > 0 {
+		diff := e.Duration - ticksToDuration(s.RunTimeTicks)
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > 5*time.Second {
+			score *= 0.5
+		}
+	}
+	return score
+}
+
+func songToPlaylistEntry(s *Song) PlaylistEntry {
+	return PlaylistEntry{
+		ID:          s.Id,
+		Title:       s.Name,
+		Artist:      songArtistNames(s),
+		Album:       s.Album,
+		Duration:    ticksToDuration(s.RunTimeTicks),
+		Location:    songPath(s),
+		ProviderIDs: s.ProviderIds,
+	}
+}
+
+func songArtistNames(s *Song) string {
+	names := make([]string, 0, len(s.Artists))
+	for _, a := range s.Artists {
+		names = append(names, a.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func songPath(s *Song) string {
+	if len(s.MediaSources) > 0 {
+		return s.MediaSources[0].Path
+	}
+	return ""
+}
+
+// streamURLItemID extracts the item ID from a stream URL generated by this client.
+func streamURLItemID(location string) string {
+	u, err := url.Parse(location)
+	if err != nil || u.Scheme == "" {
+		return ""
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i+2 < len(parts); i++ {
+		if strings.EqualFold(parts[i], "audio") && parts[i+2] == "stream" {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+func filepathToSlash(p string) string {
+	return strings.ReplaceAll(p, "\\", "/")
+}
+
+func writeM3U8(w io.Writer, name string, entries []PlaylistEntry) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "#EXTM3U")
+	if name != "" {
+		fmt.Fprintf(bw, "#PLAYLIST:%s\n", name)
+	}
+	for _, e := range entries {
+		secs := -1
+		if e.Duration > 0 {
+			secs = int(e.Duration.Round(time.Second) / time.Second)
+		}
+		title := e.Title
+		if e.Artist != "" {
+			title = e.Artist + " - " + e.Title
+		}
+		fmt.Fprintf(bw, "#EXTINF:%d,%s\n", secs, title)
+		if e.Album != "" {
+			fmt.Fprintf(bw, "#EXTALB:%s\n", e.Album)
+		}
+		fmt.Fprintln(bw, e.Location)
+	}
+	return bw.Flush()
+}
+
+func parseM3U8(r io.Reader) (string, []PlaylistEntry, error) {
+	var name string
+	var entries []PlaylistEntry
+	var cur PlaylistEntry
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		switch {
+		case line == "" || line == "#EXTM3U":
+		case strings.HasPrefix(line, "#PLAYLIST:"):
+			name = strings.TrimPrefix(line, "#PLAYLIST:")
+		case strings.HasPrefix(line, "#EXTINF:"):
+			info := strings.TrimPrefix(line, "#EXTINF:")
+			durStr, title, _ := strings.Cut(info, ",")
+			// strip any attributes following the duration, e.g. tvg-id="..."
+			durStr, _, _ = strings.Cut(durStr, " ")
+			if secs, err := strconv.ParseFloat(durStr, 64); err == nil && secs > 0 {
+				cur.Duration = time.Duration(secs * float64(time.Second))
+			}
+			if artist, t, ok := strings.Cut(title, " - "); ok {
+				cur.Artist, cur.Title = strings.TrimSpace(artist), strings.TrimSpace(t)
+			} else {
+				cur.Title = strings.TrimSpace(title)
+			}
+		case strings.HasPrefix(line, "#EXTALB:"):
+			cur.Album = strings.TrimPrefix(line, "#EXTALB:")
+		case strings.HasPrefix(line, "#"):
+			// unsupported directive or comment
+		default:
+			cur.Location = line
+			entries = append(entries, cur)
+			cur = PlaylistEntry{}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", nil, fmt.Errorf("read m3u8: %w", err)
+	}
+	return name, entries, nil
+}
+
+type xspfPlaylist struct {
+	XMLName   xml.Name    `xml:"http://xspf.org/ns/0/ playlist"`
+	Version   string      `xml:"version,attr"`
+	Title     string      `xml:"title,omitempty"`
+	TrackList []xspfTrack `xml:"trackList>track"`
+}
+
+type xspfTrack struct {
+	Location   string   `xml:"location,omitempty"`
+	Identifier []string `xml:"identifier,omitempty"`
+	Title      string   `xml:"title,omitempty"`
+	Creator    string   `xml:"creator,omitempty"`
+	Album      string   `xml:"album,omitempty"`
+	Duration   int64    `xml:"duration,omitempty"`
+}
+
+const musicBrainzRecordingURL = "https://musicbrainz.org/recording/"
+
+func writeXSPF(w io.Writer, name string, entries []PlaylistEntry) error {
+	pl := xspfPlaylist{Version: "1", Title: name}
+	for _, e := range entries {
+		t := xspfTrack{
+			Location: xspfLocation(e.Location),
+			Title:    e.Title,
+			Creator:  e.Artist,
+			Album:    e.Album,
+			Duration: e.Duration.Milliseconds(),
+		}
+		if id := e.ProviderIDs[providerMusicBrainzRecording]; id != "" {
+			t.Identifier = append(t.Identifier, musicBrainzRecordingURL+id)
+		}
+		pl.TrackList = append(pl.TrackList, t)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(pl); err != nil {
+		return fmt.Errorf("encode xspf: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func parseXSPF(r io.Reader) (string, []PlaylistEntry, error) {
+	var pl xspfPlaylist
+	if err := xml.NewDecoder(r).Decode(&pl); err != nil {
+		return "", nil, fmt.Errorf("decode xspf: %w", err)
+	}
+	entries := make([]PlaylistEntry, 0, len(pl.TrackList))
+	for _, t := range pl.TrackList {
+		e := PlaylistEntry{
+			Title:    t.Title,
+			Artist:   t.Creator,
+			Album:    t.Album,
+			Duration: time.Duration(t.Duration) * time.Millisecond,
+			Location: xspfPath(t.Location),
+		}
+		for _, ident := range t.Identifier {
+			if id, ok := strings.CutPrefix(ident, musicBrainzRecordingURL); ok {
+				e.ProviderIDs = map[string]string{providerMusicBrainzRecording: id}
+			}
+		}
+		entries = append(entries, e)
+	}
+	return pl.Title, entries, nil
+}
+
+// xspfLocation converts a file path to the URI form required by XSPF.
+func xspfLocation(loc string) string {
+	if loc == "" || strings.Contains(loc, "://") {
+		return loc
+	}
+	p := filepathToSlash(loc)
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+	return (&url.URL{Scheme: "file", Path: p}).String()
+}
+
+func xspfPath(loc string) string {
+	u, err := url.Parse(loc)
+	if err != nil || u.Scheme != "file" {
+		return loc
+	}
+	p := u.Path
+	// windows drive paths, e.g. file:///C:/Music
+	if len(p) > 2 && p[0] == '/' && p[2] == ':' {
+		p = p[1:]
+	}
+	return p
+}
+
+type playlistJSON struct {
+	Name    string              `json:"Name"`
+	Entries []playlistEntryJSON `json:"Entries"`
+}
+
+type playlistEntryJSON struct {
+	ID          string            `json:"Id,omitempty"`
+	Title       string            `json:"Title"`
+	Artist      string            `json:"Artist,omitempty"`
+	Album       string            `json:"Album,omitempty"`
+	DurationMs  int64             `json:"DurationMs,omitempty"`
+	Location    string            `json:"Location,omitempty"`
+	ProviderIds map[string]string `json:"ProviderIds,omitempty"`
+}
+
+func writePlaylistJSON(w io.Writer, name string, entries []PlaylistEntry) error {
+	pl := playlistJSON{Name: name, Entries: make([]playlistEntryJSON, 0, len(entries))}
+	for _, e := range entries {
+		pl.Entries = append(pl.Entries, playlistEntryJSON{
+			ID:          e.ID,
+			Title:       e.Title,
+			Artist:      e.Artist,
+			Album:       e.Album,
+			DurationMs:  e.Duration.Milliseconds(),
+			Location:    e.Location,
+			ProviderIds: e.ProviderIDs,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(pl); err != nil {
+		return fmt.Errorf("encode playlist json: %w", err)
+	}
+	return nil
+}
+
+func parsePlaylistJSON(r io.Reader) (string, []PlaylistEntry, error) {
+	var pl playlistJSON
+	if err := json.NewDecoder(r).Decode(&pl); err != nil {
+		return "", nil, fmt.Errorf("decode playlist json: %w", err)
+	}
+	entries := make([]PlaylistEntry, 0, len(pl.Entries))
+	for _, e := range pl.Entries {
+		entries = append(entries, PlaylistEntry{
+			ID:          e.ID,
+			Title:       e.Title,
+			Artist:      e.Artist,
+			Album:       e.Album,
+			Duration:    time.Duration(e.DurationMs) * time.Millisecond,
+			Location:    e.Location,
+			ProviderIDs: e.ProviderIds,
+		})
+	}
+	return pl.Name, entries, nil
+}
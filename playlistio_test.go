@@ -0,0 +1,189 @@
+package jellyfin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPlaylistFormats_roundTrip(t *testing.T) {
+	entries := []PlaylistEntry{
+		{
+			Title:       "Héroes",
+			Artist:      "David Bowie",
+			Album:       "Heroes",
+			Duration:    371 * time.Second,
+			Location:    "/music/David Bowie/Heroes/03 Heroes.flac",
+			ProviderIDs: map[string]string{providerMusicBrainzRecording: "a9ba1fc4-8fa8-4a0e-9d7c-7b5f3c1f0b84"},
+		},
+		{
+			Title:    "Untitled",
+			Location: "/music/Unknown/untitled.mp3",
+		},
+	}
+
+	for _, format := range []PlaylistFormat{PlaylistFormatM3U8, PlaylistFormatXSPF, PlaylistFormatJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := WritePlaylist(&buf, format, "Favorites", entries); err != nil {
+				t.Fatalf("WritePlaylist() error = %v", err)
+			}
+			name, got, err := ParsePlaylist(&buf, format)
+			if err != nil {
+				t.Fatalf("ParsePlaylist() error = %v", err)
+			}
+			if name != "Favorites" {
+				t.Errorf("got name %q, want %q", name, "Favorites")
+			}
+			if len(got) != len(entries) {
+				t.Fatalf("got %d entries, want %d", len(got), len(entries))
+			}
+			for i, e := range entries {
+				if got[i].Title != e.Title || got[i].Artist != e.Artist || got[i].Location != e.Location || got[i].Duration != e.Duration {
+					t.Errorf("entry %d: got %+v, want %+v", i, got[i], e)
+				}
+				if format != PlaylistFormatM3U8 && !reflect.DeepEqual(got[i].ProviderIDs, e.ProviderIDs) {
+					t.Errorf("entry %d: got provider IDs %v, want %v", i, got[i].ProviderIDs, e.ProviderIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestFuzzyEntryScore(t *testing.T) {
+	song := &Song{
+		Name:         "Don't Stop Me Now",
+		Artists:      []NameID{{Name: "Queen"}},
+		RunTimeTicks: durationToTicks(209 * time.Second),
+	}
+	tests := []struct {
+		name  string
+		entry PlaylistEntry
+		match bool
+	}{
+		{"exact", PlaylistEntry{Title: "Don't Stop Me Now", Artist: "Queen", Duration: 209 * time.Second}, true},
+		{"typo and case", PlaylistEntry{Title: "dont stop me now", Artist: "queen"}, true},
+		{"wrong artist", PlaylistEntry{Title: "Don't Stop Me Now", Artist: "McFly"}, false},
+		{"different song", PlaylistEntry{Title: "Bohemian Rhapsody", Artist: "Queen"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fuzzyEntryScore(tt.entry, song) >= 0.8; got != tt.match {
+				t.Errorf("fuzzyEntryScore() match = %v, want %v", got, tt.match)
+			}
+		})
+	}
+}
+
+func TestMatchPlaylistEntry(t *testing.T) {
+	var searched []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/Users/user/Items/album":
+			json.NewEncoder(w).Encode(map[string]any{"Id": "album", "Type": "MusicAlbum"})
+		case "/Users/user/Items/song":
+			json.NewEncoder(w).Encode(map[string]any{"Id": "song", "Type": "Audio"})
+		case "/Users/user/Items":
+			searched = append(searched, q.Get("AnyProviderIdEquals")+"|"+q.Get("SearchTerm"))
+			var items []map[string]any
+			if q.Get("AnyProviderIdEquals") == providerMusicBrainzRecording+".mbid" {
+				items = append(items, map[string]any{
+					"Id": "by-mbid", "Type": "Audio", "Name": "Other Title",
+					"ProviderIds": map[string]string{providerMusicBrainzRecording: "mbid"},
+				})
+			}
+			json.NewEncoder(w).Encode(map[string]any{"Items": items, "TotalRecordCount": len(items)})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	tests := []struct {
+		name         string
+		entry        PlaylistEntry
+		wantID       string
+		wantSearches []string
+	}{
+		{"by ID", PlaylistEntry{ID: "song", Title: "Title"}, "song", nil},
+		{
+			"non-audio ID falls back to provider ID",
+			PlaylistEntry{ID: "album", Title: "Title", ProviderIDs: map[string]string{providerMusicBrainzRecording: "mbid"}},
+			"by-mbid",
+			[]string{providerMusicBrainzRecording + ".mbid|"},
+		},
+		{
+			"unknown provider ID falls back to title",
+			PlaylistEntry{Title: "Title", ProviderIDs: map[string]string{providerMusicBrainzTrack: "unknown"}},
+			"",
+			[]string{providerMusicBrainzTrack + ".unknown|", "|Title"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searched = nil
+			song, err := c.matchPlaylistEntry(context.Background(), tt.entry)
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotID := ""
+			if song != nil {
+				gotID = song.Id
+			}
+			if gotID != tt.wantID {
+				t.Errorf("got song %q, want %q", gotID, tt.wantID)
+			}
+			if !reflect.DeepEqual(searched, tt.wantSearches) {
+				t.Errorf("got searches %q, want %q", searched, tt.wantSearches)
+			}
+		})
+	}
+}
+
+func TestMatchPlaylistEntry_LookupError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	if _, err := c.matchPlaylistEntry(context.Background(), PlaylistEntry{ID: "song"}); err == nil {
+		t.Error("expected the lookup error, not a missing match")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("got request %s with a canceled context", r.URL.Path)
+	})
+	if _, err := c.matchPlaylistEntry(ctx, PlaylistEntry{ID: "song"}); err == nil {
+		t.Error("expected error with a canceled context")
+	}
+}
+
+func TestImportPlaylist_NoMatches(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("got %s %s, want no playlist created", r.Method, r.URL.Path)
+		}
+		if r.URL.Path == "/Users/user/Items" {
+			json.NewEncoder(w).Encode(map[string]any{"Items": []any{}})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	var buf bytes.Buffer
+	if err := WritePlaylist(&buf, PlaylistFormatJSON, "Mix", []PlaylistEntry{{ID: "gone", Title: "Gone"}}); err != nil {
+		t.Fatal(err)
+	}
+	result, err := c.ImportPlaylist(context.Background(), &buf, PlaylistFormatJSON, "", false)
+	if !errors.Is(err, ErrNoPlaylistMatches) {
+		t.Fatalf("got error %v, want ErrNoPlaylistMatches", err)
+	}
+	if result == nil || result.Playlist != nil || len(result.Unmatched) != 1 {
+		t.Errorf("got result %+v, want the unmatched entry and no playlist", result)
+	}
+}
@@ -0,0 +1,157 @@
+package jellyfin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// playlistServer serves the playlists "owned", "shared" and "public", as seen by
+// a user who is not an administrator. Like Jellyfin, it only reports CanDelete
+// when the field is requested, and /Playlists/{id} has no owner. The sharing
+// information of the playlists in failing answers with a server error.
+// Each request path is sent on requests, if it is not nil.
+func playlistServer(requests chan<- string, failing ...string) http.HandlerFunc {
+	sharing := map[string]map[string]any{
+		"owned":  {"OpenAccess": false, "Shares": []any{}, "ItemIds": []string{"song"}},
+		"shared": {"OpenAccess": false, "Shares": []any{map[string]any{"UserId": "user", "CanEdit": true}}, "ItemIds": []string{}},
+		"public": {"OpenAccess": true, "Shares": []any{}, "ItemIds": []string{}},
+	}
+	item := func(r *http.Request, id string) map[string]any {
+		m := map[string]any{"Id": id, "MediaType": "Audio"}
+		if strings.Contains(r.URL.Query().Get("Fields"), "CanDelete") {
+			m["CanDelete"] = id == "owned"
+		}
+		return m
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if requests != nil {
+			requests <- r.URL.Path
+		}
+		switch {
+		case r.URL.Path == "/Users/user/Items":
+			var items []map[string]any
+			for _, id := range []string{"owned", "shared", "public"} {
+				items = append(items, item(r, id))
+			}
+			json.NewEncoder(w).Encode(map[string]any{"Items": items, "TotalRecordCount": len(items)})
+		case strings.HasPrefix(r.URL.Path, "/Playlists/"):
+			id := strings.TrimPrefix(r.URL.Path, "/Playlists/")
+			for _, f := range failing {
+				if id == f {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+			}
+			dto, ok := sharing[id]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(dto)
+		case strings.HasPrefix(r.URL.Path, "/Users/user/Items/"):
+			json.NewEncoder(w).Encode(item(r, strings.TrimPrefix(r.URL.Path, "/Users/user/Items/")))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func ownerships(playlists []*Playlist) map[string]PlaylistOwnership {
+	m := make(map[string]PlaylistOwnership, len(playlists))
+	for _, pl := range playlists {
+		m[pl.ID] = pl.Ownership
+	}
+	return m
+}
+
+func TestGetPlaylists_SingleRequest(t *testing.T) {
+	requests := make(chan string, 10)
+	c := newTestClient(t, playlistServer(requests))
+	playlists, err := c.GetPlaylists()
+	if err != nil {
+		t.Fatal(err)
+	}
+	close(requests)
+	var paths []string
+	for p := range requests {
+		paths = append(paths, p)
+	}
+	if want := []string{"/Users/user/Items"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("got requests %v, want %v", paths, want)
+	}
+	if len(playlists) != 3 || !playlists[0].CanDelete {
+		t.Errorf("got playlists %+v, want 3 with CanDelete requested", playlists)
+	}
+	for _, pl := range playlists {
+		if pl.Ownership != "" {
+			t.Errorf("got ownership %q for %s, want it unset", pl.Ownership, pl.ID)
+		}
+	}
+}
+
+func TestGetPlaylistsByOwnership(t *testing.T) {
+	c := newTestClient(t, playlistServer(nil))
+	playlists, err := c.GetPlaylistsByOwnership(PlaylistOwnershipAny)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]PlaylistOwnership{
+		"owned":  PlaylistOwnedByMe,
+		"shared": PlaylistSharedWithMe,
+		"public": PlaylistPublic,
+	}
+	if got := ownerships(playlists); !reflect.DeepEqual(got, want) {
+		t.Errorf("got ownership %v, want %v", got, want)
+	}
+
+	shared, err := c.GetPlaylistsByOwnership(PlaylistSharedWithMe)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shared) != 1 || shared[0].ID != "shared" || !shared[0].Shares[0].CanEdit {
+		t.Errorf("got shared playlists %+v, want only the shared playlist", shared)
+	}
+}
+
+func TestGetPlaylistsByOwnership_PartialFailure(t *testing.T) {
+	c := newTestClient(t, playlistServer(nil, "shared"))
+
+	playlists, err := c.GetPlaylistsByOwnership(PlaylistOwnershipAny)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || !reflect.DeepEqual(batchErr.FailedIDs(), []string{"shared"}) {
+		t.Fatalf("got error %v, want a *BatchError for the shared playlist", err)
+	}
+	want := map[string]PlaylistOwnership{"owned": PlaylistOwnedByMe, "public": PlaylistPublic}
+	if got := ownerships(playlists); !reflect.DeepEqual(got, want) {
+		t.Errorf("got ownership %v, want %v", got, want)
+	}
+}
+
+func TestGetPlaylist_SharingError(t *testing.T) {
+	c := newTestClient(t, playlistServer(nil, "owned"))
+	if _, err := c.GetPlaylist("owned"); err == nil {
+		t.Error("expected error when the sharing information fails")
+	}
+
+	// servers older than 10.9 do not have the endpoint
+	c = newTestClient(t, playlistServer(nil))
+	pl, err := c.GetPlaylist("unknown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pl.Ownership != "" {
+		t.Errorf("got ownership %q without sharing information, want unknown", pl.Ownership)
+	}
+
+	pl, err = c.GetPlaylist("owned")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pl.Ownership != PlaylistOwnedByMe {
+		t.Errorf("got ownership %q, want %q", pl.Ownership, PlaylistOwnedByMe)
+	}
+}
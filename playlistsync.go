@@ -0,0 +1,250 @@
+package jellyfin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrPlaylistConflict is returned by SetPlaylistSongs when the playlist
+// was modified by someone else while the changes were being applied.
+var ErrPlaylistConflict = errors.New("playlist was modified concurrently")
+
+// PlaylistMove moves a playlist entry to a new index.
+type PlaylistMove struct {
+	SongID         string
+	PlaylistItemID string
+	NewIndex       int
+}
+
+// PlaylistPlan describes the changes made to a playlist by SetPlaylistSongs.
+type PlaylistPlan struct {
+	// RemoveEntryIDs are the PlaylistItemIds of the removed entries.
+	RemoveEntryIDs []string
+	// AddSongIDs are the songs appended to the playlist, in order.
+	AddSongIDs []string
+	// Moves are the reorderings applied after adding, in order.
+	Moves []PlaylistMove
+}
+
+// IsEmpty returns true if the plan makes no changes.
+func (p *PlaylistPlan) IsEmpty() bool {
+	return len(p.RemoveEntryIDs) == 0 && len(p.AddSongIDs) == 0 && len(p.Moves) == 0
+}
+
+// SetPlaylistSongs changes the contents of the playlist to be exactly desiredSongIDs,
+// in order, using a minimal set of remove, add and move operations. Songs that appear
+// more than once are handled individually via their PlaylistItemId. If the playlist
+// is changed by another client while the plan is applied, an error wrapping
+// ErrPlaylistConflict is returned along with the operations applied so far.
+func (c *Client) SetPlaylistSongs(ctx context.Context, playlistID string, desiredSongIDs []string) (*PlaylistPlan, error) {
+	plan := &PlaylistPlan{}
+	current, err := c.getPlaylistSongs(ctx, playlistID)
+	if err != nil {
+		return plan, err
+	}
+
+	remove, add := planPlaylistChanges(current, desiredSongIDs)
+	expected := removeEntries(current, remove)
+
+	if len(remove) > 0 {
+		if err := c.removePlaylistEntries(ctx, playlistID, remove); err != nil {
+			return plan, err
+		}
+		plan.RemoveEntryIDs = remove
+	}
+	if len(add) > 0 {
+		if err := c.addSongsToPlaylist(ctx, playlistID, add); err != nil {
+			return plan, err
+		}
+		plan.AddSongIDs = add
+	}
+
+	current, err = c.getPlaylistSongs(ctx, playlistID)
+	if err != nil {
+		return plan, err
+	}
+	if !playlistMatchesAfterAdd(current, expected, add) {
+		return plan, fmt.Errorf("set playlist songs: %w", ErrPlaylistConflict)
+	}
+
+	for _, mv := range planPlaylistMoves(current, desiredSongIDs) {
+		if err := c.movePlaylistEntry(ctx, playlistID, mv.PlaylistItemID, mv.NewIndex); err != nil {
+			return plan, err
+		}
+		plan.Moves = append(plan.Moves, mv)
+	}
+
+	if len(plan.Moves) > 0 {
+		current, err = c.getPlaylistSongs(ctx, playlistID)
+		if err != nil {
+			return plan, err
+		}
+	}
+	if !sameSongIDs(current, desiredSongIDs) {
+		return plan, fmt.Errorf("set playlist songs: %w", ErrPlaylistConflict)
+	}
+	return plan, nil
+}
+
+// planPlaylistChanges returns the entries to remove from current and the songs
+// to append so that it contains the same multiset of songs as desired.
+// The earliest occurrences of duplicated songs are kept.
+func planPlaylistChanges(current []*Song, desired []string) (removeEntryIDs []string, addSongIDs []string) {
+	want := make(map[string]int, len(desired))
+	for _, id := range desired {
+		want[id]++
+	}
+	have := make(map[string]int, len(current))
+	for _, s := range current {
+		if have[s.Id] < want[s.Id] {
+			have[s.Id]++
+		} else {
+			removeEntryIDs = append(removeEntryIDs, s.PlaylistItemId)
+		}
+	}
+	for _, id := range desired {
+		if have[id] > 0 {
+			have[id]--
+		} else {
+			addSongIDs = append(addSongIDs, id)
+		}
+	}
+	return removeEntryIDs, addSongIDs
+}
+
+// planPlaylistMoves returns the moves that reorder current, which must contain
+// the same multiset of songs as desired, into the desired order. Only entries
+// outside a longest increasing subsequence of target positions are moved.
+func planPlaylistMoves(current []*Song, desired []string) []PlaylistMove {
+	// assign each current entry its target index; the k-th occurrence of a
+	// song in current goes to the k-th occurrence of it in desired
+	positions := make(map[string][]int, len(desired))
+	for i, id := range desired {
+		positions[id] = append(positions[id], i)
+	}
+	target := make([]int, len(current))
+	for i, s := range current {
+		target[i] = positions[s.Id][0]
+		positions[s.Id] = positions[s.Id][1:]
+	}
+
+	keep := make(map[int]bool, len(current))
+	for _, i := range longestIncreasingSubsequence(target) {
+		keep[target[i]] = true
+	}
+
+	// simulate the list of target indexes as moves are applied
+	list := append([]int(nil), target...)
+	byTarget := make([]*Song, len(desired))
+	for i, s := range current {
+		byTarget[target[i]] = s
+	}
+
+	var moves []PlaylistMove
+	for t := range desired {
+		if keep[t] {
+			continue
+		}
+		from := indexOf(list, t)
+		list = append(list[:from], list[from+1:]...)
+		to := 0
+		if t > 0 {
+			to = indexOf(list, t-1) + 1
+		}
+		list = append(list[:to], append([]int{t}, list[to:]...)...)
+		if from == to {
+			continue
+		}
+		moves = append(moves, PlaylistMove{
+			SongID:         byTarget[t].Id,
+			PlaylistItemID: byTarget[t].PlaylistItemId,
+			NewIndex:       to,
+		})
+	}
+	return moves
+}
+
+// longestIncreasingSubsequence returns the indexes into seq of a longest
+// strictly increasing subsequence.
+func longestIncreasingSubsequence(seq []int) []int {
+	tails := []int{} // indexes into seq of the smallest tail of each length
+	prev := make([]int, len(seq))
+	for i, v := range seq {
+		n := sort.Search(len(tails), func(j int) bool { return seq[tails[j]] >= v })
+		if n > 0 {
+			prev[i] = tails[n-1]
+		} else {
+			prev[i] = -1
+		}
+		if n == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[n] = i
+		}
+	}
+
+	result := make([]int, len(tails))
+	if len(tails) == 0 {
+		return result
+	}
+	for i, k := len(tails)-1, tails[len(tails)-1]; i >= 0; i, k = i-1, prev[k] {
+		result[i] = k
+	}
+	return result
+}
+
+func indexOf(list []int, v int) int {
+	for i, x := range list {
+		if x == v {
+			return i
+		}
+	}
+	return -1
+}
+
+func removeEntries(songs []*Song, entryIDs []string) []*Song {
+	remove := make(map[string]bool, len(entryIDs))
+	for _, id := range entryIDs {
+		remove[id] = true
+	}
+	kept := make([]*Song, 0, len(songs))
+	for _, s := range songs {
+		if !remove[s.PlaylistItemId] {
+			kept = append(kept, s)
+		}
+	}
+	return kept
+}
+
+// playlistMatchesAfterAdd checks that current consists of exactly the expected
+// entries followed by the added songs.
+func playlistMatchesAfterAdd(current, expected []*Song, added []string) bool {
+	if len(current) != len(expected)+len(added) {
+		return false
+	}
+	for i, s := range expected {
+		if current[i].PlaylistItemId != s.PlaylistItemId {
+			return false
+		}
+	}
+	for i, id := range added {
+		if current[len(expected)+i].Id != id {
+			return false
+		}
+	}
+	return true
+}
+
+func sameSongIDs(songs []*Song, ids []string) bool {
+	if len(songs) != len(ids) {
+		return false
+	}
+	for i, s := range songs {
+		if s.Id != ids[i] {
+			return false
+		}
+	}
+	return true
+}
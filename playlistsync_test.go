@@ -0,0 +1,74 @@
+package jellyfin
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func makePlaylist(ids ...string) []*Song {
+	songs := make([]*Song, len(ids))
+	for i, id := range ids {
+		songs[i] = &Song{Id: id, PlaylistItemId: fmt.Sprintf("entry%d", i)}
+	}
+	return songs
+}
+
+func songIDs(songs []*Song) []string {
+	ids := make([]string, len(songs))
+	for i, s := range songs {
+		ids[i] = s.Id
+	}
+	return ids
+}
+
+func TestPlanPlaylistChanges(t *testing.T) {
+	current := makePlaylist("a", "b", "a", "c", "a")
+	remove, add := planPlaylistChanges(current, []string{"a", "d", "a", "b", "d"})
+	if want := []string{"entry3", "entry4"}; !reflect.DeepEqual(remove, want) {
+		t.Errorf("got removals %v, want %v", remove, want)
+	}
+	if want := []string{"d", "d"}; !reflect.DeepEqual(add, want) {
+		t.Errorf("got additions %v, want %v", add, want)
+	}
+}
+
+func TestPlanPlaylistMoves(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   []string
+		desired   []string
+		wantMoves int
+	}{
+		{"unchanged", []string{"a", "b", "c"}, []string{"a", "b", "c"}, 0},
+		{"first to last", []string{"a", "b", "c", "d"}, []string{"b", "c", "d", "a"}, 1},
+		{"last to first", []string{"a", "b", "c", "d"}, []string{"d", "a", "b", "c"}, 1},
+		{"reversed", []string{"a", "b", "c", "d"}, []string{"d", "c", "b", "a"}, 3},
+		{"duplicates", []string{"a", "b", "a", "c"}, []string{"a", "a", "c", "b"}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := makePlaylist(tt.current...)
+			moves := planPlaylistMoves(list, tt.desired)
+			if len(moves) != tt.wantMoves {
+				t.Errorf("got %d moves, want %d: %+v", len(moves), tt.wantMoves, moves)
+			}
+
+			// apply the moves the way the server does
+			for _, mv := range moves {
+				from := -1
+				for i, s := range list {
+					if s.PlaylistItemId == mv.PlaylistItemID {
+						from = i
+					}
+				}
+				s := list[from]
+				list = append(list[:from], list[from+1:]...)
+				list = append(list[:mv.NewIndex], append([]*Song{s}, list[mv.NewIndex:]...)...)
+			}
+			if got := songIDs(list); !reflect.DeepEqual(got, tt.desired) {
+				t.Errorf("after moves got %v, want %v", got, tt.desired)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package jellyfin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type playReportKind string
+
+const (
+	reportStatus playReportKind = "status"
+	reportPlayed playReportKind = "played"
+)
+
+// playReport is a single queued report, persisted as one line of the journal.
+type playReport struct {
+	Kind          playReportKind `json:"Kind"`
+	ItemID        string         `json:"ItemId"`
+	Event         PlayEvent      `json:"Event,omitempty"`
+	PositionTicks int64          `json:"PositionTicks,omitempty"`
+	Time          time.Time      `json:"Time"`
+}
+
+// PlayReporter wraps UpdatePlayStatus and MarkPlayed so that plays
+// made while the server is unreachable are not lost. Unsent plays are
+// persisted to a journal file and replayed in order by Flush, with their
+// original timestamps.
+type PlayReporter struct {
+	client      *Client
+	journalPath string
+
+	mu      sync.Mutex
+	pending []playReport
+}
+
+// NewPlayReporter creates a PlayReporter that journals unsent reports to journalPath.
+// Any reports left in the journal from a previous run are loaded and will be
+// sent on the next Flush.
+func NewPlayReporter(client *Client, journalPath string) (*PlayReporter, error) {
+	r := &PlayReporter{client: client, journalPath: journalPath}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// UpdatePlayStatus reports a playback event. If the server cannot be reached or is
+// temporarily unavailable, a Stop is queued as the item having been played at the
+// time of the Stop, and other events are dropped, since replaying them later would
+// show a stale session on the server. Errors from sending previously queued
+// reports are returned as well.
+func (r *PlayReporter) UpdatePlayStatus(songID string, event PlayEvent, positionTicks int64) error {
+	return r.report(playReport{
+		Kind:          reportStatus,
+		ItemID:        songID,
+		Event:         event,
+		PositionTicks: positionTicks,
+		Time:          time.Now(),
+	})
+}
+
+// MarkPlayed marks the item as played at the given time, queueing the
+// report if the server cannot be reached or is temporarily unavailable. When replayed, the play is
+// recorded with the original timestamp. If at is zero, the current time is used.
+func (r *PlayReporter) MarkPlayed(id string, at time.Time) error {
+	if at.IsZero() {
+		at = time.Now()
+	}
+	return r.report(playReport{Kind: reportPlayed, ItemID: id, Time: at})
+}
+
+// Pending returns the number of reports waiting to be sent.
+func (r *PlayReporter) Pending() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.pending)
+}
+
+// Flush attempts to send all queued reports in order. It stops at the first
+// transient error (see isTransientError), leaving the remaining reports queued. Reports rejected
+// by the server are dropped and their errors returned.
+func (r *PlayReporter) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.flushLocked()
+}
+
+// Run periodically flushes queued reports until ctx is canceled.
+func (r *PlayReporter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if r.Pending() > 0 {
+				r.Flush()
+			}
+		}
+	}
+}
+
+func (r *PlayReporter) report(rep playReport) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// preserve ordering: nothing can be sent directly while older reports are queued
+	var flushErr error
+	if len(r.pending) > 0 {
+		flushErr = r.flushLocked()
+	}
+	if len(r.pending) == 0 {
+		err := r.send(rep)
+		if err == nil || !isTransientError(err) {
+			return errors.Join(flushErr, err)
+		}
+	}
+
+	queued, ok := queuedReport(rep)
+	if !ok {
+		return flushErr
+	}
+	r.pending = append(r.pending, queued)
+	return errors.Join(flushErr, r.save())
+}
+
+func (r *PlayReporter) flushLocked() error {
+	var errs []error
+	sent := 0
+	for _, rep := range r.pending {
+		if err := r.send(rep); err != nil {
+			if isTransientError(err) {
+				break
+			}
+			errs = append(errs, err)
+		}
+		sent++
+	}
+	if sent > 0 {
+		r.pending = r.pending[sent:]
+		if err := r.save(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *PlayReporter) send(rep playReport) error {
+	switch rep.Kind {
+	case reportStatus:
+		return r.client.UpdatePlayStatus(rep.ItemID, rep.Event, rep.PositionTicks)
+	case reportPlayed:
+		return r.client.MarkPlayed(rep.ItemID, rep.Time)
+	default:
+		return fmt.Errorf("unknown play report kind: %s", rep.Kind)
+	}
+}
+
+// queuedReport returns the report to queue in place of rep, which could not be
+// sent, and false if rep should be dropped. Playback status events describe a
+// live session, so only the play recorded by a Stop is kept.
+func queuedReport(rep playReport) (playReport, bool) {
+	switch {
+	case rep.Kind == reportPlayed:
+		return rep, true
+	case rep.Kind == reportStatus && rep.Event == Stop:
+		return playReport{Kind: reportPlayed, ItemID: rep.ItemID, Time: rep.Time}, true
+	}
+	return playReport{}, false
+}
+
+func (r *PlayReporter) load() error {
+	f, err := os.Open(r.journalPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("open play report journal: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rep playReport
+		if err := json.Unmarshal(scanner.Bytes(), &rep); err != nil {
+			// skip a partially written line rather than losing the whole journal
+			continue
+		}
+		if queued, ok := queuedReport(rep); ok {
+			r.pending = append(r.pending, queued)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read play report journal: %w", err)
+	}
+	return nil
+}
+
+// save atomically rewrites the journal with the current queue.
+func (r *PlayReporter) save() error {
+	if len(r.pending) == 0 {
+		if err := os.Remove(r.journalPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove play report journal: %w", err)
+		}
+		return nil
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(r.journalPath), filepath.Base(r.journalPath)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("write play report journal: %w", err)
+	}
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, rep := range r.pending {
+		if err = enc.Encode(rep); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), r.journalPath)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write play report journal: %w", err)
+	}
+	return nil
+}
+
+// isTransientError reports whether err was caused by failing to reach the
+// server, or by the server being overloaded or failing internally, so that
+// the request may succeed later, as opposed to the server rejecting it.
+func isTransientError(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+	code := responseStatus(err)
+	return code >= 500 || code == http.StatusRequestTimeout || code == http.StatusTooManyRequests
+}
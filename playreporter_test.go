@@ -0,0 +1,129 @@
+package jellyfin
+
+import (
+	"net/http"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPlayReporter_replaysOnlyPlays(t *testing.T) {
+	var up atomic.Bool
+	var mu sync.Mutex
+	var requests []*http.Request
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if !up.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		mu.Lock()
+		requests = append(requests, r)
+		mu.Unlock()
+	})
+	r, err := NewPlayReporter(c, filepath.Join(t.TempDir(), "plays.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ev := range []PlayEvent{Start, TimeUpdate, Pause, Unpause, TimeUpdate} {
+		if err := r.UpdatePlayStatus("song", ev, 10); err != nil {
+			t.Fatalf("%s: %v", ev, err)
+		}
+	}
+	if r.Pending() != 0 {
+		t.Fatalf("got %d pending reports, want progress events dropped", r.Pending())
+	}
+	before := time.Now()
+	if err := r.UpdatePlayStatus("song", Stop, 20); err != nil {
+		t.Fatal(err)
+	}
+	if r.Pending() != 1 {
+		t.Fatalf("got %d pending reports, want the Stop queued as a play", r.Pending())
+	}
+	stopped := r.pending[0].Time
+	if stopped.Before(before) {
+		t.Errorf("got play time %v, want the time of the Stop", stopped)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	up.Store(true)
+	if err := r.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if len(requests) != 1 {
+		t.Fatalf("got %d requests on replay, want 1", len(requests))
+	}
+	req := requests[0]
+	if req.URL.Path != "/Users/user/PlayedItems/song" {
+		t.Errorf("replayed %s %s, want the play to be marked", req.Method, req.URL.Path)
+	}
+	if got, want := req.URL.Query().Get("DatePlayed"), formatJellyfinTime(stopped); got != want {
+		t.Errorf("got DatePlayed %q, want the original time %q", got, want)
+	}
+}
+
+func TestPlayReporter_journalsWhenUnreachable(t *testing.T) {
+	client, err := NewClient("http://127.0.0.1:1", "test", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	journal := filepath.Join(t.TempDir(), "plays.jsonl")
+
+	r, err := NewPlayReporter(client, journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.UpdatePlayStatus("song", Stop, 0); err != nil {
+		t.Fatalf("expected report to be queued, got error: %v", err)
+	}
+	if err := r.MarkPlayed("song", time.Time{}); err != nil {
+		t.Fatalf("expected report to be queued, got error: %v", err)
+	}
+	if r.Pending() != 2 {
+		t.Fatalf("got %d pending reports, want 2", r.Pending())
+	}
+
+	reloaded, err := NewPlayReporter(client, journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Pending() != 2 {
+		t.Errorf("got %d reports from journal, want 2", reloaded.Pending())
+	}
+}
+
+func TestPlayReporter_statusCodes(t *testing.T) {
+	var status atomic.Int32
+	var requests atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(int(status.Load()))
+	})
+	r, err := NewPlayReporter(c, filepath.Join(t.TempDir(), "plays.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, code := range []int{http.StatusServiceUnavailable, http.StatusRequestTimeout, http.StatusTooManyRequests} {
+		status.Store(int32(code))
+		if err := r.MarkPlayed("song-"+http.StatusText(code), time.Time{}); err != nil {
+			t.Errorf("%d: expected report to be queued, got error: %v", code, err)
+		}
+	}
+	if r.Pending() != 3 {
+		t.Fatalf("got %d pending reports, want 3", r.Pending())
+	}
+
+	// rejected reports are dropped, and the errors of
+	// queued reports are returned by the next report
+	status.Store(http.StatusBadRequest)
+	requests.Store(0)
+	if err := r.MarkPlayed("song", time.Time{}); err == nil {
+		t.Error("expected errors for rejected reports")
+	}
+	if r.Pending() != 0 || requests.Load() != 4 {
+		t.Errorf("got %d pending reports after %d requests, want 0 after 4", r.Pending(), requests.Load())
+	}
+}
@@ -1,6 +1,10 @@
 package jellyfin
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 type SortField string
 
@@ -13,6 +17,33 @@ const (
 	SortByDateCreated     SortField = "DateCreated"
 	SortByDatePlayed      SortField = "DatePlayed"
 	SortByCommunityRating SortField = "CommunityRating"
+
+	SortByDefault              SortField = "Default"
+	SortByTitle                SortField = "Name"
+	SortByAlbum                SortField = "Album"
+	SortByAlbumArtist          SortField = "AlbumArtist"
+	SortByTrackArtist          SortField = "Artist"
+	SortByProductionYear       SortField = "ProductionYear"
+	SortByPremiereDate         SortField = "PremiereDate"
+	SortByStartDate            SortField = "StartDate"
+	SortByDateLastContentAdded SortField = "DateLastContentAdded"
+	SortByRuntime              SortField = "Runtime"
+	SortByParentIndexNumber    SortField = "ParentIndexNumber"
+	SortByIndexNumber          SortField = "IndexNumber"
+	SortByOfficialRating       SortField = "OfficialRating"
+	SortByCriticRating         SortField = "CriticRating"
+	SortByStudio               SortField = "Studio"
+	SortByIsFolder             SortField = "IsFolder"
+	SortByIsPlayed             SortField = "IsPlayed"
+	SortByIsUnplayed           SortField = "IsUnplayed"
+	SortByIsFavoriteOrLiked    SortField = "IsFavoriteOrLiked"
+	SortByVideoBitRate         SortField = "VideoBitRate"
+	SortByAiredEpisodeOrder    SortField = "AiredEpisodeOrder"
+	SortByAirTime              SortField = "AirTime"
+	SortBySeriesSortName       SortField = "SeriesSortName"
+	SortBySeriesDatePlayed     SortField = "SeriesDatePlayed"
+	SortBySimilarityScore      SortField = "SimilarityScore"
+	SortBySearchScore          SortField = "SearchScore"
 )
 
 type SortOrder string
@@ -22,10 +53,39 @@ const (
 	SortDesc SortOrder = "DESC"
 )
 
+// SortKey is a single field to sort by, with its own direction.
+type SortKey struct {
+	Field SortField
+	Mode  SortOrder
+}
+
 // Sort describes sorting
 type Sort struct {
 	Field SortField
 	Mode  SortOrder
+
+	// Keys sorts by multiple fields, in order of priority, e.g. album artist,
+	// then year, then album name. If set, Field and Mode are ignored.
+	Keys []SortKey
+
+	// Seed makes a SortByRandom order deterministic, so that paging through
+	// a shuffled list neither repeats nor skips items. Applies to GetSongs
+	// and GetAlbums. If 0, the server shuffles each page independently.
+	Seed int64
+}
+
+func (s Sort) isZero() bool {
+	return s.Field == "" && s.Mode == "" && len(s.Keys) == 0
+}
+
+func (s Sort) isSeededRandom() bool {
+	if s.Seed == 0 {
+		return false
+	}
+	if len(s.Keys) > 0 {
+		return len(s.Keys) == 1 && s.Keys[0].Field == SortByRandom
+	}
+	return s.Field == SortByRandom
 }
 
 type Paging struct {
@@ -53,13 +113,126 @@ type Filter struct {
 	// Genres contains list of genres to include.
 	Genres []string
 	// YearRange contains two elements, items must be within these boundaries.
+	// It is matched against the premiere date, combined with MinPremiereDate
+	// and MaxPremiereDate if those are set.
 	YearRange [2]int
+
+	// Include only results from any of the given artists (in addition to ArtistID).
+	ArtistIDs []string
+	// Include only results from any of the given albums.
+	AlbumIDs []string
+	// Include only results with any of the given genre IDs.
+	GenreIDs []string
+	// Exclude results from any of the given artists.
+	ExcludeArtistIDs []string
+	// Exclude the given items from the results.
+	ExcludeItemIDs []string
+	// Include only results with any of the given tags.
+	Tags []string
+	// Include only results from any of the given studios (record labels for music).
+	Studios []string
+
+	// Include only results whose sort name starts with the given string.
+	NameStartsWith string
+	// Include only results whose sort name sorts before the given string.
+	NameLessThan string
+
+	// Include only results with at least the given community rating (0-10).
+	MinCommunityRating float64
+	// Include only items added or changed since the given time.
+	MinDateLastSaved time.Time
+	// Include only items whose user data (plays, favorites, ...) changed since the given time.
+	MinDateLastSavedForUser time.Time
+	// Include only items released within the given time range. Either bound may be zero.
+	MinPremiereDate time.Time
+	MaxPremiereDate time.Time
+
+	// Include only songs that have lyrics.
+	HasLyrics bool
+	// Include only results in any of the given containers, e.g. "flac".
+	Containers []string
+	// Include only results with any of the given audio codecs, e.g. "alac".
+	AudioCodecs []string
+
+	// Resolution restricts video items to a resolution class.
+	Resolution VideoResolution
+	// HDR includes only video items with an HDR video stream. The server cannot
+	// filter by HDR, so the client scans the matching items to fill each page.
+	HDR bool
+	// MaxParentalRating excludes video items with an official rating
+	// above the given one, e.g. "PG-13".
+	MaxParentalRating string
 }
 
+type VideoResolution string
+
+const (
+	ResolutionAny VideoResolution = ""
+	ResolutionSD  VideoResolution = "SD"
+	ResolutionHD  VideoResolution = "HD"
+	Resolution4K  VideoResolution = "4K"
+)
+
 type QueryOpts struct {
-	Paging Paging
-	Filter Filter
-	Sort   Sort
+	Paging   Paging
+	Filter   Filter
+	Sort     Sort
+	Response ResponseOpts
+}
+
+type ResponsePreset int
+
+const (
+	// PresetDefault requests the default fields of each call.
+	PresetDefault ResponsePreset = iota
+	// PresetMinimal requests only names, IDs and basic metadata,
+	// without images, user data or the total record count.
+	PresetMinimal
+	// PresetList requests the fields needed for list views,
+	// without media sources or people, and at most one image per type.
+	PresetList
+	// PresetDetail requests all fields useful for detail views.
+	PresetDetail
+)
+
+// ResponseOpts controls how much data the server includes in responses,
+// which can greatly reduce response sizes for large libraries.
+// Model fields that are not requested are left zero-valued.
+type ResponseOpts struct {
+	Preset ResponsePreset
+	// Fields overrides the optional fields requested, e.g. "Genres", "MediaSources".
+	// If nil, the fields of the preset are used.
+	Fields []string
+	// DisableImages omits image tags from the response.
+	DisableImages bool
+	// DisableUserData omits user data (play count, favorite, ...) from the response.
+	DisableUserData bool
+	// ImageTypeLimit limits the number of images returned per image type. 0 is unlimited.
+	ImageTypeLimit int
+	// DisableTotalRecordCount skips counting all matching items, which speeds up paged queries.
+	DisableTotalRecordCount bool
+}
+
+// Validate checks the filter for invalid or contradicting values.
+func (f Filter) Validate() error {
+	if !f.yearRangeValid() {
+		return fmt.Errorf("invalid year range: %d-%d", f.YearRange[0], f.YearRange[1])
+	}
+	if f.MinCommunityRating < 0 || f.MinCommunityRating > 10 {
+		return fmt.Errorf("invalid minimum community rating: %v", f.MinCommunityRating)
+	}
+	if !f.MinPremiereDate.IsZero() && !f.MaxPremiereDate.IsZero() && f.MinPremiereDate.After(f.MaxPremiereDate) {
+		return errors.New("invalid premiere date range: minimum is after maximum")
+	}
+	if f.FilterPlayed != "" && f.FilterPlayed != FilterIsPlayed && f.FilterPlayed != FilterIsNotPlayed {
+		return fmt.Errorf("invalid play status filter: %s", f.FilterPlayed)
+	}
+	switch f.Resolution {
+	case ResolutionAny, ResolutionSD, ResolutionHD, Resolution4K:
+	default:
+		return fmt.Errorf("invalid resolution filter: %s", f.Resolution)
+	}
+	return nil
 }
 
 func (f Filter) yearRangeValid() bool {
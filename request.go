@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type params map[string]string
@@ -22,22 +24,30 @@ func (c *Client) defaultParams() params {
 }
 
 func (p params) setSorting(sort Sort) {
-
-	field := "SortName"
-	order := "Ascending"
-
-	if sort.Mode == SortAsc {
-		order = "Ascending"
-	} else if sort.Mode == SortDesc {
-		order = "Descending"
+	keys := sort.Keys
+	if len(keys) == 0 {
+		keys = []SortKey{{Field: sort.Field, Mode: sort.Mode}}
 	}
 
-	if sort.Field != "" {
-		field = string(sort.Field)
+	var fields, orders []string
+	for _, key := range keys {
+		field := "SortName"
+		if key.Field != "" {
+			field = string(key.Field)
+		}
+		order := "Ascending"
+		if key.Mode == SortDesc {
+			order = "Descending"
+		}
+		// some fields, e.g. SortByYear, consist of several server sort keys
+		for _, f := range strings.Split(field, ",") {
+			fields = append(fields, f)
+			orders = append(orders, order)
+		}
 	}
 
-	p["SortBy"] = field
-	p["SortOrder"] = order
+	p["SortBy"] = strings.Join(fields, ",")
+	p["SortOrder"] = strings.Join(orders, ",")
 }
 
 func (p params) setPaging(paging Paging) {
@@ -59,11 +69,60 @@ func (p params) setIncludeFields(fields ...string) {
 	p["Fields"] = strings.Join(fields, ",")
 }
 
+// fieldSet contains the optional fields requested for an item type by each response preset.
+type fieldSet struct {
+	list     []string
+	standard []string
+	detail   []string
+}
+
+func (p params) setResponse(opts ResponseOpts, fields fieldSet) {
+	disableImages := opts.DisableImages
+	disableUserData := opts.DisableUserData
+	disableCount := opts.DisableTotalRecordCount
+	imageTypeLimit := opts.ImageTypeLimit
+
+	include := fields.standard
+	switch opts.Preset {
+	case PresetMinimal:
+		include = nil
+		disableImages, disableUserData, disableCount = true, true, true
+	case PresetList:
+		include = fields.list
+		if imageTypeLimit == 0 {
+			imageTypeLimit = 1
+		}
+	case PresetDetail:
+		include = fields.detail
+	}
+	if opts.Fields != nil {
+		include = opts.Fields
+	}
+
+	p.setIncludeFields(include...)
+	if disableImages {
+		p["EnableImages"] = "false"
+	}
+	if disableUserData {
+		p["EnableUserData"] = "false"
+	}
+	if imageTypeLimit > 0 {
+		p["ImageTypeLimit"] = strconv.Itoa(imageTypeLimit)
+	}
+	if disableCount {
+		p["EnableTotalRecordCount"] = "false"
+	}
+}
+
 func (p params) enableRecursive() {
 	p["Recursive"] = "true"
 }
 
-func (p params) setFilter(tItem mediaItemType, filter Filter) {
+func (p params) setFilter(tItem mediaItemType, filter Filter) error {
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+
 	f := ""
 	if filter.Favorite {
 		f = appendFilter(f, "IsFavorite", ",")
@@ -79,27 +138,6 @@ func (p params) setFilter(tItem mediaItemType, filter Filter) {
 		}
 	}
 
-	if tItem != mediaTypeArtist {
-		if filter.yearRangeValid() && filter.YearRange[0] > 0 {
-			years := ""
-			totalYears := filter.YearRange[1] - filter.YearRange[0]
-			if totalYears == 0 {
-				years = strconv.Itoa(filter.YearRange[0])
-			} else {
-				var sb strings.Builder
-				for i := 0; i < totalYears+1; i++ {
-					if i > 0 {
-						sb.WriteString(",")
-					}
-					year := filter.YearRange[0] + i
-					sb.WriteString(strconv.Itoa(year))
-				}
-				years = sb.String()
-			}
-			p["Years"] = years
-		}
-	}
-
 	if len(filter.Genres) > 0 {
 		p["Genres"] = strings.Join(filter.Genres, "|")
 	}
@@ -108,13 +146,84 @@ func (p params) setFilter(tItem mediaItemType, filter Filter) {
 		p["Filters"] = f
 	}
 
+	artistIDs := filter.ArtistIDs
 	if filter.ArtistID != "" {
-		p["ArtistIds"] = filter.ArtistID
+		artistIDs = append([]string{filter.ArtistID}, artistIDs...)
 	}
+	p.setList("ArtistIds", artistIDs, "|")
+	p.setList("AlbumIds", filter.AlbumIDs, "|")
+	p.setList("GenreIds", filter.GenreIDs, "|")
+	p.setList("ExcludeArtistIds", filter.ExcludeArtistIDs, "|")
+	p.setList("ExcludeItemIds", filter.ExcludeItemIDs, ",")
+	p.setList("Tags", filter.Tags, "|")
+	p.setList("Studios", filter.Studios, "|")
+	p.setList("Containers", filter.Containers, ",")
+	p.setList("AudioCodecs", filter.AudioCodecs, ",")
 
 	if filter.ParentID != "" {
 		p["ParentId"] = filter.ParentID
 	}
+
+	if filter.NameStartsWith != "" {
+		p["NameStartsWith"] = filter.NameStartsWith
+	}
+	if filter.NameLessThan != "" {
+		p["NameLessThan"] = filter.NameLessThan
+	}
+	if filter.MinCommunityRating > 0 {
+		p["MinCommunityRating"] = strconv.FormatFloat(filter.MinCommunityRating, 'f', -1, 64)
+	}
+	p.setTime("MinDateLastSaved", filter.MinDateLastSaved)
+	p.setTime("MinDateLastSavedForUser", filter.MinDateLastSavedForUser)
+	// a year range is sent as premiere dates, rather than as a list of every year
+	minPremiere, maxPremiere := filter.MinPremiereDate, filter.MaxPremiereDate
+	if tItem != mediaTypeArtist && filter.YearRange[0] > 0 {
+		first := time.Date(filter.YearRange[0], time.January, 1, 0, 0, 0, 0, time.UTC)
+		if first.After(minPremiere) {
+			minPremiere = first
+		}
+		// the last tick of the last year
+		last := time.Date(filter.YearRange[1]+1, time.January, 1, 0, 0, 0, 0, time.UTC).Add(-100 * time.Nanosecond)
+		if maxPremiere.IsZero() || last.Before(maxPremiere) {
+			maxPremiere = last
+		}
+	}
+	p.setTime("MinPremiereDate", minPremiere)
+	p.setTime("MaxPremiereDate", maxPremiere)
+	if filter.HasLyrics {
+		p["HasLyrics"] = "true"
+	}
+
+	if isVideoType(tItem) {
+		switch filter.Resolution {
+		case ResolutionSD:
+			p["IsHd"] = "false"
+		case ResolutionHD:
+			p["IsHd"] = "true"
+		case Resolution4K:
+			p["Is4K"] = "true"
+		}
+		if filter.MaxParentalRating != "" {
+			p["MaxOfficialRating"] = filter.MaxParentalRating
+		}
+	}
+	return nil
+}
+
+func (p params) setList(key string, values []string, separator string) {
+	if len(values) > 0 {
+		p[key] = strings.Join(values, separator)
+	}
+}
+
+func (p params) setTime(key string, t time.Time) {
+	if !t.IsZero() {
+		p[key] = formatJellyfinTime(t)
+	}
+}
+
+func isVideoType(tItem mediaItemType) bool {
+	return tItem == mediaTypeMovie || tItem == mediaTypeSeries || tItem == mediaTypeEpisode
 }
 
 func appendFilter(old, new string, separator string) string {
@@ -125,7 +234,11 @@ func appendFilter(old, new string, separator string) string {
 }
 
 func (c *Client) get(url string, params params) (io.ReadCloser, error) {
-	resp, err := c.makeDo(context.Background(), http.MethodGet, url, nil, params, nil)
+	return c.getContext(context.Background(), url, params)
+}
+
+func (c *Client) getContext(ctx context.Context, url string, params params) (io.ReadCloser, error) {
+	resp, err := c.makeDo(ctx, http.MethodGet, url, nil, params, nil)
 	if resp != nil {
 		return resp.Body, err
 	}
@@ -133,7 +246,11 @@ func (c *Client) get(url string, params params) (io.ReadCloser, error) {
 }
 
 func (c *Client) delete(url string, params params) (io.ReadCloser, error) {
-	resp, err := c.makeDo(context.Background(), http.MethodDelete, url, nil, params, nil)
+	return c.deleteContext(context.Background(), url, params)
+}
+
+func (c *Client) deleteContext(ctx context.Context, url string, params params) (io.ReadCloser, error) {
+	resp, err := c.makeDo(ctx, http.MethodDelete, url, nil, params, nil)
 	if resp != nil {
 		return resp.Body, err
 	}
@@ -141,11 +258,15 @@ func (c *Client) delete(url string, params params) (io.ReadCloser, error) {
 }
 
 func (c *Client) post(url string, params params, body any) (io.ReadCloser, error) {
+	return c.postContext(context.Background(), url, params, body)
+}
+
+func (c *Client) postContext(ctx context.Context, url string, params params, body any) (io.ReadCloser, error) {
 	bodyEnc, err := json.Marshal(body)
 	if err != nil {
 		return nil, fmt.Errorf("marshal POST body: %v", err)
 	}
-	resp, err := c.makeDo(context.Background(), http.MethodPost, url, bodyEnc, params, nil)
+	resp, err := c.makeDo(ctx, http.MethodPost, url, bodyEnc, params, nil)
 	if resp != nil {
 		return resp.Body, err
 	}
@@ -257,5 +378,28 @@ func checkResponse(resp *http.Response) (*http.Response, error) {
 		errMsg = errServerError
 	}
 
-	return resp, fmt.Errorf("%s, code: %s, msg: %s", errMsg, resp.Status, msg)
+	return resp, &statusError{
+		code: resp.StatusCode,
+		msg:  fmt.Sprintf("%s, code: %s, msg: %s", errMsg, resp.Status, msg),
+	}
+}
+
+// statusError is returned for responses with an unexpected status code.
+type statusError struct {
+	code int
+	msg  string
+}
+
+func (e *statusError) Error() string {
+	return e.msg
+}
+
+// responseStatus returns the status code of the response that caused err,
+// or 0 if err was not caused by an unexpected status code.
+func responseStatus(err error) int {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.code
+	}
+	return 0
 }
@@ -4,6 +4,7 @@ import (
 	"net/url"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestClient_encodeGETUrl(t *testing.T) {
@@ -80,3 +81,167 @@ func TestClient_encodeGETUrl(t *testing.T) {
 		})
 	}
 }
+
+func TestParams_setFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		tItem   mediaItemType
+		filter  Filter
+		want    params
+		wantErr bool
+	}{
+		{
+			name:   "POSITIVE - year range and genres",
+			tItem:  mediaTypeAlbum,
+			filter: Filter{YearRange: [2]int{1999, 2001}, Genres: []string{"Rock", "Pop"}},
+			want: params{
+				"MinPremiereDate": "1999-01-01T00:00:00.0000000Z",
+				"MaxPremiereDate": "2001-12-31T23:59:59.9999999Z",
+				"Genres":          "Rock|Pop",
+			},
+		},
+		{
+			name:  "POSITIVE - year range within premiere dates",
+			tItem: mediaTypeAlbum,
+			filter: Filter{
+				YearRange:       [2]int{1999, 2001},
+				MinPremiereDate: time.Date(2000, time.June, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want: params{
+				"MinPremiereDate": "2000-06-01T00:00:00.0000000Z",
+				"MaxPremiereDate": "2001-12-31T23:59:59.9999999Z",
+			},
+		},
+		{
+			name:  "POSITIVE - multiple artists and exclusions",
+			tItem: mediaTypeAudio,
+			filter: Filter{
+				ArtistID:         "a1",
+				ArtistIDs:        []string{"a2", "a3"},
+				ExcludeArtistIDs: []string{"a4"},
+				ExcludeItemIDs:   []string{"i1", "i2"},
+				NameStartsWith:   "B",
+			},
+			want: params{
+				"ArtistIds":        "a1|a2|a3",
+				"ExcludeArtistIds": "a4",
+				"ExcludeItemIds":   "i1,i2",
+				"NameStartsWith":   "B",
+			},
+		},
+		{
+			name:  "POSITIVE - rating and dates",
+			tItem: mediaTypeAlbum,
+			filter: Filter{
+				MinCommunityRating: 7.5,
+				MinDateLastSaved:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				Favorite:           true,
+			},
+			want: params{
+				"MinCommunityRating": "7.5",
+				"MinDateLastSaved":   "2024-01-02T03:04:05.0000000Z",
+				"Filters":            "IsFavorite",
+			},
+		},
+		{
+			name:    "NEGATIVE - inverted year range",
+			tItem:   mediaTypeAlbum,
+			filter:  Filter{YearRange: [2]int{2010, 2000}},
+			wantErr: true,
+		},
+		{
+			name:    "NEGATIVE - community rating out of range",
+			tItem:   mediaTypeAlbum,
+			filter:  Filter{MinCommunityRating: 11},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := params{}
+			err := got.setFilter(tt.tItem, tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("params.setFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("params.setFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParams_setSorting(t *testing.T) {
+	tests := []struct {
+		name string
+		sort Sort
+		want params
+	}{
+		{
+			name: "POSITIVE - defaults to ascending sort name",
+			sort: Sort{},
+			want: params{"SortBy": "SortName", "SortOrder": "Ascending"},
+		},
+		{
+			name: "POSITIVE - compound field repeats order",
+			sort: Sort{Field: SortByYear, Mode: SortDesc},
+			want: params{"SortBy": "ProductionYear,PremiereDate", "SortOrder": "Descending,Descending"},
+		},
+		{
+			name: "POSITIVE - multiple keys with own directions",
+			sort: Sort{Keys: []SortKey{
+				{Field: SortByAlbumArtist, Mode: SortAsc},
+				{Field: SortByProductionYear, Mode: SortDesc},
+				{Field: SortByAlbum},
+			}},
+			want: params{"SortBy": "AlbumArtist,ProductionYear,Album", "SortOrder": "Ascending,Descending,Ascending"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := params{}
+			got.setSorting(tt.sort)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("params.setSorting() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParams_setResponse(t *testing.T) {
+	fields := fieldSet{list: []string{"Genres"}, standard: []string{"Genres", "MediaSources"}, detail: []string{"Genres", "MediaSources", "Path"}}
+	tests := []struct {
+		name string
+		opts ResponseOpts
+		want params
+	}{
+		{
+			name: "POSITIVE - default fields",
+			opts: ResponseOpts{},
+			want: params{"Fields": "Genres,MediaSources"},
+		},
+		{
+			name: "POSITIVE - minimal preset",
+			opts: ResponseOpts{Preset: PresetMinimal},
+			want: params{"Fields": "", "EnableImages": "false", "EnableUserData": "false", "EnableTotalRecordCount": "false"},
+		},
+		{
+			name: "POSITIVE - list preset limits images",
+			opts: ResponseOpts{Preset: PresetList},
+			want: params{"Fields": "Genres", "ImageTypeLimit": "1"},
+		},
+		{
+			name: "POSITIVE - explicit fields override preset",
+			opts: ResponseOpts{Preset: PresetDetail, Fields: []string{"Overview"}, DisableUserData: true},
+			want: params{"Fields": "Overview", "EnableUserData": "false"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := params{}
+			got.setResponse(tt.opts, fields)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("params.setResponse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
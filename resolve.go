@@ -0,0 +1,179 @@
+package jellyfin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	resolveProbeTimeout = 5 * time.Second
+	publicInfoPath      = "/System/Info/Public"
+)
+
+// commonBasePaths are tried when the user input does not include a path.
+var commonBasePaths = []string{"", "/jellyfin"}
+
+// ResolvedServer is a Jellyfin server found by ResolveServer.
+type ResolvedServer struct {
+	// URL is the base URL to connect to.
+	URL string
+	// Info is the public information the server returned.
+	Info *PingResponse
+	// Latency is the round trip time of the probe to URL.
+	Latency time.Duration
+	// IsLocal is true if URL is the server's LocalAddress.
+	IsLocal bool
+}
+
+// NewClient creates a Client for the resolved server.
+func (s *ResolvedServer) NewClient(clientName, clientVersion string, opts ...ClientOptionFunc) (*Client, error) {
+	return NewClient(s.URL, clientName, clientVersion, opts...)
+}
+
+// ResolveServer finds the Jellyfin server a user means by an incomplete address,
+// such as "jellyfin.example.com" or "192.168.1.10". If the input does not include
+// them, the https and http schemes, the default ports 8096 and 8920, and common base
+// paths are tried, and redirects are followed. Candidates are validated by checking
+// that the server identifies itself as Jellyfin. If the server reports a local
+// address that is reachable and faster than the public address, the local address
+// is chosen. The options are applied to the clients used for probing.
+func ResolveServer(ctx context.Context, userInput string, opts ...ClientOptionFunc) (*ResolvedServer, error) {
+	candidates, err := serverCandidates(userInput)
+	if err != nil {
+		return nil, err
+	}
+
+	probeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type probeResult struct {
+		i      int
+		server *ResolvedServer
+		err    error
+	}
+	probes := make(chan probeResult, len(candidates))
+	for i, candidate := range candidates {
+		go func(i int, candidate string) {
+			r, err := probeServer(probeCtx, candidate, opts)
+			probes <- probeResult{i, r, err}
+		}(i, candidate)
+	}
+
+	// candidates are in order of preference, e.g. https before http, so the
+	// best one is known as soon as all more preferred candidates have failed;
+	// the remaining probes are then canceled
+	results := make([]*ResolvedServer, len(candidates))
+	errs := make([]error, len(candidates))
+	done := make([]bool, len(candidates))
+	var best *ResolvedServer
+	for next := 0; next < len(candidates) && best == nil; {
+		p := <-probes
+		results[p.i], errs[p.i], done[p.i] = p.server, p.err, true
+		for ; next < len(candidates) && done[next]; next++ {
+			if results[next] != nil {
+				best = results[next]
+				break
+			}
+		}
+	}
+	cancel()
+	if best == nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("no Jellyfin server found at %s: %w", userInput, errors.Join(errs...))
+	}
+
+	local := strings.TrimSuffix(best.Info.LocalAddress, "/")
+	if local != "" && local != best.URL {
+		if r, err := probeServer(ctx, local, opts); err == nil && r.Info.Id == best.Info.Id && r.Latency < best.Latency {
+			r.IsLocal = true
+			return r, nil
+		}
+	}
+	return best, nil
+}
+
+// serverCandidates returns the base URLs to try for the user input, most preferred first.
+func serverCandidates(userInput string) ([]string, error) {
+	input := strings.TrimSpace(userInput)
+	if input == "" {
+		return nil, errors.New("server address must be provided")
+	}
+	schemes := []string{"https", "http"}
+	if i := strings.Index(input, "://"); i >= 0 {
+		schemes = []string{strings.ToLower(input[:i])}
+		input = input[i+3:]
+	}
+	u, err := url.Parse("http://" + input)
+	if err != nil || u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid server address: %s", userInput)
+	}
+
+	paths := commonBasePaths
+	if p := strings.TrimSuffix(u.Path, "/"); p != "" {
+		paths = []string{p}
+	}
+
+	var candidates []string
+	for _, scheme := range schemes {
+		if scheme != "http" && scheme != "https" {
+			return nil, fmt.Errorf("unsupported scheme: %s", scheme)
+		}
+		hosts := []string{u.Host}
+		if u.Port() == "" {
+			if scheme == "https" {
+				hosts = []string{u.Host, u.Host + ":8920"}
+			} else {
+				hosts = []string{u.Host + ":8096", u.Host}
+			}
+		}
+		for _, host := range hosts {
+			for _, path := range paths {
+				candidates = append(candidates, scheme+"://"+host+path)
+			}
+		}
+	}
+	return candidates, nil
+}
+
+// probeServer fetches the public server information from a base URL,
+// returning the base URL after any redirects.
+func probeServer(ctx context.Context, baseURL string, opts []ClientOptionFunc) (*ResolvedServer, error) {
+	client, err := NewClient(baseURL, "", "", opts...)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, resolveProbeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := client.makeDo(ctx, http.MethodGet, publicInfoPath, nil, nil, nil)
+	if err != nil {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	info := &PingResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(info); err != nil {
+		return nil, fmt.Errorf("%s: invalid json response: %v", baseURL, err)
+	}
+	latency := time.Since(start)
+	if !strings.Contains(strings.ToLower(info.ProductName), "jellyfin") {
+		return nil, fmt.Errorf("%s: not a Jellyfin server: %q", baseURL, info.ProductName)
+	}
+
+	final := *resp.Request.URL
+	final.RawQuery = ""
+	final.Path = strings.TrimSuffix(final.Path, publicInfoPath)
+	final.RawPath = ""
+	return &ResolvedServer{URL: strings.TrimSuffix(final.String(), "/"), Info: info, Latency: latency}, nil
+}
@@ -0,0 +1,135 @@
+package jellyfin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServerCandidates(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{
+			input: "media.example.com",
+			want: []string{
+				"https://media.example.com", "https://media.example.com/jellyfin",
+				"https://media.example.com:8920", "https://media.example.com:8920/jellyfin",
+				"http://media.example.com:8096", "http://media.example.com:8096/jellyfin",
+				"http://media.example.com", "http://media.example.com/jellyfin",
+			},
+		},
+		{
+			input: " http://192.168.1.10:8096/ ",
+			want:  []string{"http://192.168.1.10:8096", "http://192.168.1.10:8096/jellyfin"},
+		},
+		{
+			input: "example.com/media/",
+			want: []string{
+				"https://example.com/media", "https://example.com:8920/media",
+				"http://example.com:8096/media", "http://example.com/media",
+			},
+		},
+	}
+	for _, tt := range tests {
+		got, err := serverCandidates(tt.input)
+		if err != nil {
+			t.Errorf("%q: %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%q: got %v, want %v", tt.input, got, tt.want)
+		}
+	}
+
+	if _, err := serverCandidates("ftp://example.com"); err == nil {
+		t.Error("expected error for unsupported scheme")
+	}
+}
+
+func publicInfoHandler(info PingResponse, delay time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		json.NewEncoder(w).Encode(info)
+	}
+}
+
+func TestResolveServer(t *testing.T) {
+	local := httptest.NewServer(publicInfoHandler(PingResponse{Id: "abc", ProductName: "Jellyfin Server"}, 0))
+	defer local.Close()
+
+	mux := http.NewServeMux()
+	mux.Handle("/jellyfin/System/Info/Public", publicInfoHandler(PingResponse{
+		Id:           "abc",
+		ProductName:  "Jellyfin Server",
+		LocalAddress: local.URL,
+	}, 50*time.Millisecond))
+	mux.Handle("/System/Info/Public", http.RedirectHandler("/jellyfin/System/Info/Public", http.StatusFound))
+	public := httptest.NewServer(mux)
+	defer public.Close()
+
+	ctx := context.Background()
+	addr := strings.TrimPrefix(public.URL, "http://")
+	r, err := ResolveServer(ctx, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.URL != local.URL || !r.IsLocal {
+		t.Errorf("got %s (local %v), want faster local address %s", r.URL, r.IsLocal, local.URL)
+	}
+
+	// without a reachable local address, the redirected public address is used
+	local.Close()
+	r, err = ResolveServer(ctx, addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := public.URL + "/jellyfin"; r.URL != want || r.IsLocal {
+		t.Errorf("got %s (local %v), want %s", r.URL, r.IsLocal, want)
+	}
+}
+
+func TestResolveServer_NotJellyfin(t *testing.T) {
+	other := httptest.NewServer(publicInfoHandler(PingResponse{Id: "x", ProductName: "Emby Server"}, 0))
+	defer other.Close()
+
+	if _, err := ResolveServer(context.Background(), other.URL); err == nil {
+		t.Error("expected error for a server that is not Jellyfin")
+	}
+}
+
+func TestResolveServer_DoesNotWaitForLessPreferred(t *testing.T) {
+	canceled := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.Handle(publicInfoPath, publicInfoHandler(PingResponse{Id: "abc", ProductName: "Jellyfin Server"}, 50*time.Millisecond))
+	// the less preferred /jellyfin candidate hangs until its probe is canceled
+	mux.HandleFunc("/jellyfin"+publicInfoPath, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(canceled)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	start := time.Now()
+	resolved, err := ResolveServer(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("took %v, want to return as soon as the preferred candidate answered", elapsed)
+	}
+	if resolved.URL != srv.URL {
+		t.Errorf("got URL %s, want %s", resolved.URL, srv.URL)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Error("the less preferred probe was not canceled")
+	}
+}
@@ -50,10 +50,17 @@ func (c *Client) GetItemImage(itemID, imageTag string, size, quality int) (image
 }
 
 func (c *Client) GetStreamURL(id string, transcodeOptions *TranscodeOptions) (string, error) {
+	return c.audioStreamURL(id, randomKey(32), "", transcodeOptions)
+}
+
+func (c *Client) audioStreamURL(id, playSessionID, mediaSourceID string, transcodeOptions *TranscodeOptions) (string, error) {
 	path := fmt.Sprintf("/audio/%s/stream", id)
 	params := c.defaultParams()
-	params["playSessionId"] = randomKey(32)
+	params["playSessionId"] = playSessionID
 	params["api_key"] = c.token
+	if mediaSourceID != "" {
+		params["mediaSourceId"] = mediaSourceID
+	}
 	if transcodeOptions != nil {
 		params["container"] = transcodeOptions.Container
 		params["audioCodec"] = transcodeOptions.AudioCodec
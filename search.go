@@ -1,9 +1,12 @@
 package jellyfin
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
+	"sync"
 )
 
 func searchDtoToItems(rc io.ReadCloser, itemType mediaItemType) (*SearchResult, error) {
@@ -17,6 +20,8 @@ func searchDtoToItems(rc io.ReadCloser, itemType mediaItemType) (*SearchResult,
 		result = &artists{}
 	case mediaTypePlaylist:
 		result = &playlists{}
+	case mediaTypeMusicVideo:
+		result = &musicVideos{}
 	default:
 		return nil, fmt.Errorf("unknown item type: %s", itemType)
 	}
@@ -30,12 +35,19 @@ func searchDtoToItems(rc io.ReadCloser, itemType mediaItemType) (*SearchResult,
 	switch itemType {
 	case mediaTypeAudio:
 		searchResult.Songs = result.(*songs).Songs
+		searchResult.TotalSongs = result.(*songs).TotalSongs
 	case mediaTypeAlbum:
 		searchResult.Albums = result.(*albums).Albums
+		searchResult.TotalAlbums = result.(*albums).TotalAlbums
 	case mediaTypeArtist:
 		searchResult.Artists = result.(*artists).Artists
+		searchResult.TotalArtists = result.(*artists).TotalArtists
 	case mediaTypePlaylist:
 		searchResult.Playlists = result.(*playlists).Playlists
+		searchResult.TotalPlaylists = result.(*playlists).TotalPlaylists
+	case mediaTypeMusicVideo:
+		searchResult.MusicVideos = result.(*musicVideos).MusicVideos
+		searchResult.TotalMusicVideos = result.(*musicVideos).TotalMusicVideos
 	}
 
 	return searchResult, nil
@@ -43,6 +55,10 @@ func searchDtoToItems(rc io.ReadCloser, itemType mediaItemType) (*SearchResult,
 
 // Search searches audio items
 func (jf *Client) Search(query string, itemType ItemType, opts QueryOpts) (*SearchResult, error) {
+	return jf.searchContext(context.Background(), query, itemType, opts)
+}
+
+func (jf *Client) searchContext(ctx context.Context, query string, itemType ItemType, opts QueryOpts) (*SearchResult, error) {
 	params := jf.defaultParams()
 	params.enableRecursive()
 	params.setPaging(opts.Paging)
@@ -52,24 +68,29 @@ func (jf *Client) Search(query string, itemType ItemType, opts QueryOpts) (*Sear
 	switch itemType {
 	case TypeArtist:
 		mediaType = mediaTypeArtist
-		params.setIncludeFields(artistIncludeFields...)
+		params.setResponse(opts.Response, artistFields)
 	case TypeAlbum:
 		mediaType = mediaTypeAlbum
-		params.setIncludeFields(albumIncludeFields...)
+		params.setResponse(opts.Response, albumFields)
 	case TypeSong:
 		mediaType = mediaTypeAudio
-		params.setIncludeFields(songIncludeFields...)
+		params.setResponse(opts.Response, songFields)
 	case TypePlaylist:
 		mediaType = mediaTypePlaylist
-		params.setIncludeFields(playlistIncludeFields...)
+		params.setResponse(opts.Response, playlistFields)
+	case TypeMusicVideo:
+		mediaType = mediaTypeMusicVideo
+		params.setResponse(opts.Response, musicVideoFields)
 	default:
 		return nil, fmt.Errorf("itemType %s not supported", itemType)
 	}
-	params.setFilter(mediaType, opts.Filter)
+	if err := params.setFilter(mediaType, opts.Filter); err != nil {
+		return nil, err
+	}
 	params.setSorting(opts.Sort)
 	params.setIncludeTypes(mediaType)
 
-	body, err := jf.get(fmt.Sprintf("/Users/%s/Items", jf.userID), params)
+	body, err := jf.getContext(ctx, fmt.Sprintf("/Users/%s/Items", jf.userID), params)
 	if body != nil {
 		defer body.Close()
 	}
@@ -79,3 +100,121 @@ func (jf *Client) Search(query string, itemType ItemType, opts QueryOpts) (*Sear
 
 	return searchDtoToItems(body, mediaType)
 }
+
+// SearchOptions configures a multi-type search with SearchAll.
+type SearchOptions struct {
+	// Types to search. Defaults to artists, albums, songs and playlists.
+	Types []ItemType
+
+	// Limit is the maximum number of results of each type. Defaults to 20.
+	Limit int
+
+	Filter   Filter
+	Response ResponseOpts
+}
+
+var defaultSearchTypes = []ItemType{TypeArtist, TypeAlbum, TypeSong, TypePlaylist}
+
+// SearchAll searches several item types concurrently and merges the results.
+// The per-type totals are set on the result, as well as the most relevant
+// match across all types in TopResult.
+func (jf *Client) SearchAll(ctx context.Context, query string, opts SearchOptions) (*SearchResult, error) {
+	types := opts.Types
+	if len(types) == 0 {
+		types = defaultSearchTypes
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	queryOpts := QueryOpts{
+		Paging:   Paging{Limit: limit},
+		Filter:   opts.Filter,
+		Response: opts.Response,
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*SearchResult, len(types))
+	errs := make([]error, len(types))
+	var wg sync.WaitGroup
+	for i, t := range types {
+		wg.Add(1)
+		go func(i int, t ItemType) {
+			defer wg.Done()
+			results[i], errs[i] = jf.searchContext(ctx, query, t, queryOpts)
+			if errs[i] != nil {
+				cancel()
+			}
+		}(i, t)
+	}
+	wg.Wait()
+
+	merged := &SearchResult{}
+	for i, r := range results {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("search %s: %w", types[i], errs[i])
+		}
+		merged.Artists = append(merged.Artists, r.Artists...)
+		merged.Albums = append(merged.Albums, r.Albums...)
+		merged.Songs = append(merged.Songs, r.Songs...)
+		merged.Playlists = append(merged.Playlists, r.Playlists...)
+		merged.MusicVideos = append(merged.MusicVideos, r.MusicVideos...)
+		merged.TotalArtists += r.TotalArtists
+		merged.TotalAlbums += r.TotalAlbums
+		merged.TotalSongs += r.TotalSongs
+		merged.TotalPlaylists += r.TotalPlaylists
+		merged.TotalMusicVideos += r.TotalMusicVideos
+	}
+	merged.TopResult = topSearchResult(query, merged)
+	return merged, nil
+}
+
+// topSearchResult picks the result whose name best matches the query.
+// Ties go to the earlier type in the order artist, album, song, playlist,
+// music video, and then to the earlier result in server order.
+func topSearchResult(query string, r *SearchResult) *SearchTopResult {
+	q := normalizeText(query)
+	var top *SearchTopResult
+	bestScore := 0.0
+	consider := func(name string, result SearchTopResult) {
+		if score := searchRelevance(q, normalizeText(name)); score > bestScore {
+			bestScore = score
+			top = &result
+		}
+	}
+	for _, a := range r.Artists {
+		consider(a.Name, SearchTopResult{Type: TypeArtist, Artist: a})
+	}
+	for _, a := range r.Albums {
+		consider(a.Name, SearchTopResult{Type: TypeAlbum, Album: a})
+	}
+	for _, s := range r.Songs {
+		consider(s.Name, SearchTopResult{Type: TypeSong, Song: s})
+	}
+	for _, p := range r.Playlists {
+		consider(p.Name, SearchTopResult{Type: TypePlaylist, Playlist: p})
+	}
+	for _, v := range r.MusicVideos {
+		consider(v.Name, SearchTopResult{Type: TypeMusicVideo, MusicVideo: v})
+	}
+	return top
+}
+
+// searchRelevance scores how well a normalized name matches a normalized query,
+// from 0 to 1. Exact matches score highest, then prefix and word prefix matches.
+func searchRelevance(query, name string) float64 {
+	switch {
+	case query == "" || name == "":
+		return 0
+	case name == query:
+		return 1
+	case strings.HasPrefix(name, query):
+		return 0.9
+	case strings.Contains(" "+name, " "+query):
+		return 0.8
+	default:
+		return 0.7 * stringSimilarity(query, name)
+	}
+}
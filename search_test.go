@@ -0,0 +1,68 @@
+package jellyfin
+
+import "testing"
+
+func TestTopSearchResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		result   *SearchResult
+		wantType ItemType
+		wantName string
+	}{
+		{
+			name:  "exact match beats prefix match",
+			query: "Help",
+			result: &SearchResult{
+				Artists: []*Artist{{Name: "Helplessness Blues"}},
+				Songs:   []*Song{{Name: "Help!"}},
+			},
+			wantType: TypeSong,
+			wantName: "Help!",
+		},
+		{
+			name:  "tie goes to artist",
+			query: "weezer",
+			result: &SearchResult{
+				Artists: []*Artist{{Name: "Weezer"}},
+				Albums:  []*Album{{Name: "Weezer"}},
+			},
+			wantType: TypeArtist,
+			wantName: "Weezer",
+		},
+		{
+			name:  "accents are ignored",
+			query: "sigur ros",
+			result: &SearchResult{
+				Albums:  []*Album{{Name: "Takk..."}},
+				Artists: []*Artist{{Name: "Sigur Rós"}},
+			},
+			wantType: TypeArtist,
+			wantName: "Sigur Rós",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			top := topSearchResult(tt.query, tt.result)
+			if top == nil {
+				t.Fatal("got no top result")
+			}
+			var name string
+			switch top.Type {
+			case TypeArtist:
+				name = top.Artist.Name
+			case TypeAlbum:
+				name = top.Album.Name
+			case TypeSong:
+				name = top.Song.Name
+			}
+			if top.Type != tt.wantType || name != tt.wantName {
+				t.Errorf("got %s %q, want %s %q", top.Type, name, tt.wantType, tt.wantName)
+			}
+		})
+	}
+
+	if top := topSearchResult("anything", &SearchResult{}); top != nil {
+		t.Errorf("got top result %+v for empty result", top)
+	}
+}
@@ -0,0 +1,357 @@
+package jellyfin
+
+import (
+	"container/heap"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+type indexField uint8
+
+const (
+	fieldTitle indexField = iota
+	fieldArtist
+	fieldAlbum
+)
+
+// fieldBoosts weights matches by the field they occur in: title > artist > album.
+var fieldBoosts = [...]float32{fieldTitle: 3, fieldArtist: 2, fieldAlbum: 1}
+
+const (
+	qualityExact  = 1.0
+	qualityPrefix = 0.8
+	qualityTypo1  = 0.6
+	qualityTypo2  = 0.4
+
+	// exactTitleBonus is added when the whole title equals the query.
+	exactTitleBonus = 4
+)
+
+type posting struct {
+	doc   int32
+	field indexField
+}
+
+type indexDoc struct {
+	itemType ItemType
+	idx      int32
+	title    string // normalized
+}
+
+// SearchIndex is an in-memory full-text index over songs, albums and artists,
+// e.g. from a locally cached library. Matching folds case and accents, tolerates
+// typos and prefixes, and ranks title matches above artist and album matches.
+// A SearchIndex is immutable and safe for concurrent use; build a new one
+// when the indexed items change.
+type SearchIndex struct {
+	artists []*Artist
+	albums  []*Album
+	songs   []*Song
+
+	docs     []indexDoc
+	postings map[string][]posting
+	// terms holds the sorted vocabulary, for prefix lookups.
+	terms []string
+	// termsByLen holds the vocabulary by rune count, for typo lookups.
+	termsByLen map[int][][]rune
+
+	scratch sync.Pool
+}
+
+// NewSearchIndex indexes the given artists, albums and songs.
+func NewSearchIndex(artists []*Artist, albums []*Album, songs []*Song) *SearchIndex {
+	idx := &SearchIndex{
+		artists:    artists,
+		albums:     albums,
+		songs:      songs,
+		postings:   make(map[string][]posting),
+		termsByLen: make(map[int][][]rune),
+	}
+	for i, a := range artists {
+		doc := idx.addDoc(TypeArtist, i, a.Name)
+		idx.addField(doc, fieldTitle, a.Name)
+	}
+	for i, a := range albums {
+		doc := idx.addDoc(TypeAlbum, i, a.Name)
+		idx.addField(doc, fieldTitle, a.Name)
+		for _, artist := range a.Artists {
+			idx.addField(doc, fieldArtist, artist.Name)
+		}
+	}
+	for i, s := range songs {
+		doc := idx.addDoc(TypeSong, i, s.Name)
+		idx.addField(doc, fieldTitle, s.Name)
+		for _, artist := range s.Artists {
+			idx.addField(doc, fieldArtist, artist.Name)
+		}
+		for _, artist := range s.AlbumArtists {
+			idx.addField(doc, fieldArtist, artist.Name)
+		}
+		idx.addField(doc, fieldAlbum, s.Album)
+	}
+
+	idx.terms = make([]string, 0, len(idx.postings))
+	for term := range idx.postings {
+		idx.terms = append(idx.terms, term)
+		r := []rune(term)
+		idx.termsByLen[len(r)] = append(idx.termsByLen[len(r)], r)
+	}
+	sort.Strings(idx.terms)
+	return idx
+}
+
+func (idx *SearchIndex) addDoc(t ItemType, i int, title string) int32 {
+	idx.docs = append(idx.docs, indexDoc{itemType: t, idx: int32(i), title: normalizeText(title)})
+	return int32(len(idx.docs) - 1)
+}
+
+func (idx *SearchIndex) addField(doc int32, field indexField, text string) {
+	for _, token := range tokenize(text) {
+		list := idx.postings[token]
+		// skip duplicates, e.g. a song's artist that is also its album artist
+		if n := len(list); n > 0 && list[n-1].doc == doc && list[n-1].field == field {
+			continue
+		}
+		idx.postings[token] = append(list, posting{doc: doc, field: field})
+	}
+}
+
+type termMatch struct {
+	term    string
+	quality float32
+}
+
+// expand returns the indexed terms matching a query token: the token itself,
+// terms it is a prefix of, and terms within a small edit distance.
+func (idx *SearchIndex) expand(token string) []termMatch {
+	var matches []termMatch
+	seen := map[string]bool{token: true}
+	if _, ok := idx.postings[token]; ok {
+		matches = append(matches, termMatch{token, qualityExact})
+	}
+
+	// prefix matches support partially typed words, e.g. "beat" for "beatles"
+	for i := sort.SearchStrings(idx.terms, token); i < len(idx.terms) && strings.HasPrefix(idx.terms[i], token); i++ {
+		if !seen[idx.terms[i]] {
+			seen[idx.terms[i]] = true
+			matches = append(matches, termMatch{idx.terms[i], qualityPrefix})
+		}
+	}
+
+	n := utf8.RuneCountInString(token)
+	maxDist := 0
+	switch {
+	case n >= 8:
+		maxDist = 2
+	case n >= 4:
+		maxDist = 1
+	}
+	if maxDist == 0 {
+		return matches
+	}
+	r := []rune(token)
+	for l := n - maxDist; l <= n+maxDist; l++ {
+		for _, term := range idx.termsByLen[l] {
+			d := boundedLevenshtein(r, term, maxDist)
+			if d == 0 || d > maxDist {
+				continue
+			}
+			if s := string(term); !seen[s] {
+				seen[s] = true
+				q := float32(qualityTypo1)
+				if d == 2 {
+					q = qualityTypo2
+				}
+				matches = append(matches, termMatch{s, q})
+			}
+		}
+	}
+	return matches
+}
+
+type scoredDoc struct {
+	doc   int32
+	score float32
+}
+
+// searchScratch holds the per-document accumulators of a search. They are
+// pooled, since allocating them for every query dominates short queries.
+type searchScratch struct {
+	total      []float32
+	best       []float32
+	hits       []int32
+	touched    []int32
+	candidates []int32
+}
+
+// Search returns the items matching all words of the query, best matches first,
+// with at most limit items of each type (0 for no limit). The totals of the
+// result are the number of matches of each type, and TopResult is the best match.
+func (idx *SearchIndex) Search(query string, limit int) *SearchResult {
+	result := &SearchResult{}
+	tokens := dedupeStrings(tokenize(query))
+	if len(tokens) == 0 || len(idx.docs) == 0 {
+		return result
+	}
+
+	s := idx.getScratch()
+	defer idx.scratch.Put(s)
+	for i, token := range tokens {
+		s.touched = s.touched[:0]
+		for _, m := range idx.expand(token) {
+			for _, p := range idx.postings[m.term] {
+				// only documents matching all previous tokens can still match
+				if s.hits[p.doc] != int32(i) {
+					continue
+				}
+				score := m.quality * fieldBoosts[p.field]
+				if s.best[p.doc] == 0 {
+					s.touched = append(s.touched, p.doc)
+				}
+				if score > s.best[p.doc] {
+					s.best[p.doc] = score
+				}
+			}
+		}
+		for _, d := range s.touched {
+			s.total[d] += s.best[d]
+			s.hits[d]++
+			s.best[d] = 0
+		}
+		if i == 0 {
+			s.candidates = append(s.candidates[:0], s.touched...)
+		}
+	}
+
+	normQuery := strings.Join(tokens, " ")
+	var artists, albums, songs []scoredDoc
+	for _, d := range s.candidates {
+		if int(s.hits[d]) == len(tokens) {
+			score := s.total[d]
+			if idx.docs[d].title == normQuery {
+				score += exactTitleBonus
+			}
+			m := scoredDoc{doc: d, score: score}
+			switch idx.docs[d].itemType {
+			case TypeArtist:
+				artists = append(artists, m)
+			case TypeAlbum:
+				albums = append(albums, m)
+			case TypeSong:
+				songs = append(songs, m)
+			}
+		}
+		s.total[d] = 0
+		s.hits[d] = 0
+	}
+
+	result.TotalArtists, result.TotalAlbums, result.TotalSongs = len(artists), len(albums), len(songs)
+	artists, albums, songs = idx.topDocs(artists, limit), idx.topDocs(albums, limit), idx.topDocs(songs, limit)
+	for _, m := range artists {
+		result.Artists = append(result.Artists, idx.artists[idx.docs[m.doc].idx])
+	}
+	for _, m := range albums {
+		result.Albums = append(result.Albums, idx.albums[idx.docs[m.doc].idx])
+	}
+	for _, m := range songs {
+		result.Songs = append(result.Songs, idx.songs[idx.docs[m.doc].idx])
+	}
+	result.TopResult = idx.topResult(artists, albums, songs)
+	return result
+}
+
+func (idx *SearchIndex) getScratch() *searchScratch {
+	if s, ok := idx.scratch.Get().(*searchScratch); ok {
+		return s
+	}
+	return &searchScratch{
+		total: make([]float32, len(idx.docs)),
+		best:  make([]float32, len(idx.docs)),
+		hits:  make([]int32, len(idx.docs)),
+	}
+}
+
+// ranksBefore orders matches by score, then by more specific (shorter)
+// title, then by index order.
+func (idx *SearchIndex) ranksBefore(a, b scoredDoc) bool {
+	if a.score != b.score {
+		return a.score > b.score
+	}
+	if la, lb := len(idx.docs[a.doc].title), len(idx.docs[b.doc].title); la != lb {
+		return la < lb
+	}
+	return a.doc < b.doc
+}
+
+// topDocs returns the best limit matches in rank order, or all if limit <= 0.
+// It selects them with a heap rather than sorting all matches, which matters
+// for short queries matching a large part of the index.
+func (idx *SearchIndex) topDocs(docs []scoredDoc, limit int) []scoredDoc {
+	if limit > 0 && len(docs) > limit {
+		h := &docHeap{idx: idx, docs: make([]scoredDoc, 0, limit)}
+		for _, d := range docs {
+			if len(h.docs) < limit {
+				heap.Push(h, d)
+			} else if idx.ranksBefore(d, h.docs[0]) {
+				h.docs[0] = d
+				heap.Fix(h, 0)
+			}
+		}
+		docs = h.docs
+	}
+	sort.Slice(docs, func(i, j int) bool { return idx.ranksBefore(docs[i], docs[j]) })
+	return docs
+}
+
+// docHeap keeps the worst ranked match on top.
+type docHeap struct {
+	idx  *SearchIndex
+	docs []scoredDoc
+}
+
+func (h *docHeap) Len() int           { return len(h.docs) }
+func (h *docHeap) Less(i, j int) bool { return h.idx.ranksBefore(h.docs[j], h.docs[i]) }
+func (h *docHeap) Swap(i, j int)      { h.docs[i], h.docs[j] = h.docs[j], h.docs[i] }
+func (h *docHeap) Push(x any)         { h.docs = append(h.docs, x.(scoredDoc)) }
+func (h *docHeap) Pop() any {
+	d := h.docs[len(h.docs)-1]
+	h.docs = h.docs[:len(h.docs)-1]
+	return d
+}
+
+// topResult returns the best match, given the ranked matches of each type,
+// preferring artists, then albums, then songs among equally scored matches.
+func (idx *SearchIndex) topResult(artists, albums, songs []scoredDoc) *SearchTopResult {
+	var top *scoredDoc
+	for _, docs := range [][]scoredDoc{artists, albums, songs} {
+		if len(docs) > 0 && (top == nil || docs[0].score > top.score) {
+			top = &docs[0]
+		}
+	}
+	if top == nil {
+		return nil
+	}
+	doc := idx.docs[top.doc]
+	switch doc.itemType {
+	case TypeArtist:
+		return &SearchTopResult{Type: TypeArtist, Artist: idx.artists[doc.idx]}
+	case TypeAlbum:
+		return &SearchTopResult{Type: TypeAlbum, Album: idx.albums[doc.idx]}
+	default:
+		return &SearchTopResult{Type: TypeSong, Song: idx.songs[doc.idx]}
+	}
+}
+
+func dedupeStrings(s []string) []string {
+	seen := make(map[string]bool, len(s))
+	out := s[:0]
+	for _, v := range s {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
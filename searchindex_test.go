@@ -0,0 +1,116 @@
+package jellyfin
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func testSearchIndex() *SearchIndex {
+	beatles := NameID{ID: "ar1", Name: "The Beatles"}
+	sigur := NameID{ID: "ar2", Name: "Sigur Rós"}
+	return NewSearchIndex(
+		[]*Artist{{ID: "ar1", Name: "The Beatles"}, {ID: "ar2", Name: "Sigur Rós"}},
+		[]*Album{
+			{ID: "al1", Name: "Abbey Road", Artists: []NameID{beatles}},
+			{ID: "al2", Name: "Ágætis byrjun", Artists: []NameID{sigur}},
+			{ID: "al3", Name: "Help!", Artists: []NameID{beatles}},
+		},
+		[]*Song{
+			{Id: "s1", Name: "Something", Album: "Abbey Road", Artists: []NameID{beatles}},
+			{Id: "s2", Name: "Svefn-g-englar", Album: "Ágætis byrjun", Artists: []NameID{sigur}},
+			{Id: "s3", Name: "Help!", Album: "Help!", Artists: []NameID{beatles}},
+			{Id: "s4", Name: "Abbey Road Medley", Album: "Live", Artists: []NameID{{ID: "ar3", Name: "Cover Band"}}},
+		},
+	)
+}
+
+func TestSearchIndex(t *testing.T) {
+	idx := testSearchIndex()
+	tests := []struct {
+		query     string
+		wantTop   string
+		wantSongs []string
+	}{
+		{query: "beatles", wantTop: "ar1", wantSongs: []string{"s3", "s1"}},
+		{query: "sigur ros", wantTop: "ar2", wantSongs: []string{"s2"}},
+		{query: "agaetis", wantTop: "al2", wantSongs: []string{"s2"}},
+		{query: "somthing", wantTop: "s1", wantSongs: []string{"s1"}},
+		{query: "abbey road", wantTop: "al1", wantSongs: []string{"s4", "s1"}},
+		{query: "help beat", wantTop: "al3", wantSongs: []string{"s3"}},
+		{query: "zzz", wantTop: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			res := idx.Search(tt.query, 0)
+			top := ""
+			if res.TopResult != nil {
+				switch res.TopResult.Type {
+				case TypeArtist:
+					top = res.TopResult.Artist.ID
+				case TypeAlbum:
+					top = res.TopResult.Album.ID
+				case TypeSong:
+					top = res.TopResult.Song.Id
+				}
+			}
+			if top != tt.wantTop {
+				t.Errorf("got top result %q, want %q", top, tt.wantTop)
+			}
+			var songs []string
+			for _, s := range res.Songs {
+				songs = append(songs, s.Id)
+			}
+			if fmt.Sprint(songs) != fmt.Sprint(tt.wantSongs) {
+				t.Errorf("got songs %v, want %v", songs, tt.wantSongs)
+			}
+			if res.TotalSongs != len(tt.wantSongs) {
+				t.Errorf("got %d total songs, want %d", res.TotalSongs, len(tt.wantSongs))
+			}
+		})
+	}
+}
+
+func TestSearchIndex_LongQuery(t *testing.T) {
+	words := make([]string, 300)
+	for i := range words {
+		words[i] = fmt.Sprintf("w%d", i)
+	}
+	text := strings.Join(words, " ")
+	idx := NewSearchIndex(nil, nil, []*Song{{Id: "long", Name: text}, {Id: "short", Name: "w1"}})
+	for i := 0; i < 2; i++ {
+		// the second search reuses the pooled scratch space
+		res := idx.Search(text, 1)
+		if res.TotalSongs != 1 || len(res.Songs) != 1 || res.Songs[0].Id != "long" {
+			t.Errorf("search %d: got %d songs %+v, want only the long song", i, res.TotalSongs, res.Songs)
+		}
+	}
+	res := idx.Search("w1", 1)
+	if res.TotalSongs != 2 || len(res.Songs) != 1 || res.Songs[0].Id != "short" {
+		t.Errorf("got %d songs %+v, want the short song of 2", res.TotalSongs, res.Songs)
+	}
+}
+
+func BenchmarkSearchIndex(b *testing.B) {
+	words := []string{"love", "night", "blue", "dream", "fire", "heart", "river", "light", "song", "road",
+		"stone", "rain", "summer", "dance", "gold", "city", "wild", "moon", "star", "ocean"}
+	word := func(i int) string { return fmt.Sprintf("%s%d", words[i%len(words)], i%997) }
+	var songs []*Song
+	for i := 0; i < 200000; i++ {
+		songs = append(songs, &Song{
+			Id:      fmt.Sprint(i),
+			Name:    word(i) + " " + word(i/7),
+			Album:   word(i / 12),
+			Artists: []NameID{{Name: word(i / 150)}},
+		})
+	}
+	idx := NewSearchIndex(nil, nil, songs)
+	for _, query := range []string{"hart rivr", "heart", "h"} {
+		b.Run(query, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				idx.Search(query, 20)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package jellyfin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+var (
+	seriesIncludeFields  = []string{"Genres", "DateCreated", "ChildCount", "UserData", "Overview", "OfficialRating", "CommunityRating", "ProviderIds"}
+	seasonIncludeFields  = []string{"ChildCount", "UserData", "Overview"}
+	episodeIncludeFields = []string{"DateCreated", "UserData", "Overview", "OfficialRating", "CommunityRating", "PremiereDate", "MediaSources"}
+
+	seriesFields = fieldSet{
+		list:     []string{"Genres", "DateCreated", "ChildCount", "OfficialRating"},
+		standard: seriesIncludeFields,
+		detail:   append(append([]string{}, seriesIncludeFields...), "Studios", "People", "PremiereDate", "EndDate", "Status"),
+	}
+)
+
+// GetShows returns TV series with given sort, filter, and paging options.
+func (c *Client) GetShows(opts QueryOpts) ([]*Series, error) {
+	params := c.defaultParams()
+	params.enableRecursive()
+	params.setPaging(opts.Paging)
+	params.setSorting(opts.Sort)
+	if err := params.setFilter(mediaTypeSeries, opts.Filter); err != nil {
+		return nil, err
+	}
+	params.setIncludeTypes(mediaTypeSeries)
+	params.setResponse(opts.Response, seriesFields)
+	resp, err := c.get(fmt.Sprintf("/Users/%s/Items", c.userID), params)
+	if err != nil {
+		return nil, fmt.Errorf("get shows: %v", err)
+	}
+	defer resp.Close()
+
+	dto := seriesList{}
+	if err := json.NewDecoder(resp).Decode(&dto); err != nil {
+		return nil, fmt.Errorf("decode json: %v", err)
+	}
+	return dto.Series, nil
+}
+
+// GetShow returns the TV series with full metadata, including studios and people.
+func (c *Client) GetShow(seriesID string) (*Series, error) {
+	series := &Series{}
+	if err := c.getItemByID(seriesID, series, seriesFields.detail...); err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+// GetSeasons returns the seasons of a TV series in order.
+func (c *Client) GetSeasons(seriesID string) ([]*Season, error) {
+	params := c.defaultParams()
+	params.setIncludeFields(seasonIncludeFields...)
+	resp, err := c.get(fmt.Sprintf("/Shows/%s/Seasons", seriesID), params)
+	if err != nil {
+		return nil, fmt.Errorf("get seasons: %v", err)
+	}
+	defer resp.Close()
+
+	dto := seasons{}
+	if err := json.NewDecoder(resp).Decode(&dto); err != nil {
+		return nil, fmt.Errorf("decode json: %v", err)
+	}
+	return dto.Seasons, nil
+}
+
+// GetEpisodes returns the episodes of a TV series in airing order.
+// If seasonID is non-empty, only the episodes of that season are returned.
+func (c *Client) GetEpisodes(seriesID, seasonID string, paging Paging) ([]*Episode, error) {
+	params := c.defaultParams()
+	params.setPaging(paging)
+	params.setIncludeFields(episodeIncludeFields...)
+	if seasonID != "" {
+		params["SeasonId"] = seasonID
+	}
+	resp, err := c.get(fmt.Sprintf("/Shows/%s/Episodes", seriesID), params)
+	if err != nil {
+		return nil, fmt.Errorf("get episodes: %v", err)
+	}
+	defer resp.Close()
+
+	return c.parseEpisodes(resp)
+}
+
+// GetNextUp returns the next unwatched episode of each series the user is watching.
+// If seriesID is non-empty, only the next episode of that series is returned.
+func (c *Client) GetNextUp(seriesID string, paging Paging) ([]*Episode, error) {
+	params := c.defaultParams()
+	params.setPaging(paging)
+	params.setIncludeFields(episodeIncludeFields...)
+	if seriesID != "" {
+		params["SeriesId"] = seriesID
+	}
+	resp, err := c.get("/Shows/NextUp", params)
+	if err != nil {
+		return nil, fmt.Errorf("get next up: %v", err)
+	}
+	defer resp.Close()
+
+	return c.parseEpisodes(resp)
+}
+
+// GetResumeEpisodes returns partially watched episodes, most recently watched first.
+// The resume position is in UserData.PlaybackPositionTicks.
+func (c *Client) GetResumeEpisodes(paging Paging) ([]*Episode, error) {
+	resp, err := c.getResumeItems(mediaTypeEpisode, episodeIncludeFields, paging)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	return c.parseEpisodes(resp)
+}
+
+// GetResumeMovies returns partially watched movies, most recently watched first.
+// The resume position is in UserData.PlaybackPositionTicks.
+func (c *Client) GetResumeMovies(paging Paging) ([]*Movie, error) {
+	resp, err := c.getResumeItems(mediaTypeMovie, movieIncludeFields, paging)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	dto := movies{}
+	if err := json.NewDecoder(resp).Decode(&dto); err != nil {
+		return nil, fmt.Errorf("decode json: %v", err)
+	}
+	return dto.Movies, nil
+}
+
+func (c *Client) getResumeItems(itemType mediaItemType, includeFields []string, paging Paging) (io.ReadCloser, error) {
+	params := c.defaultParams()
+	params.enableRecursive()
+	params.setPaging(paging)
+	params.setIncludeTypes(itemType)
+	params.setIncludeFields(includeFields...)
+	resp, err := c.get(fmt.Sprintf("/Users/%s/Items/Resume", c.userID), params)
+	if err != nil {
+		return nil, fmt.Errorf("get resume items: %v", err)
+	}
+	return resp, nil
+}
+
+func (c *Client) parseEpisodes(resp io.Reader) ([]*Episode, error) {
+	dto := episodes{}
+	if err := json.NewDecoder(resp).Decode(&dto); err != nil {
+		return nil, fmt.Errorf("parse episodes: %v", err)
+	}
+	return dto.Episodes, nil
+}
@@ -0,0 +1,80 @@
+package jellyfin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestShows(t *testing.T) {
+	var path string
+	var query url.Values
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.Path, r.URL.Query()
+		json.NewEncoder(w).Encode(map[string]any{"Items": []map[string]string{{"Id": "x"}}, "TotalRecordCount": 1})
+	})
+
+	tests := []struct {
+		name       string
+		call       func() (int, error)
+		wantPath   string
+		wantParams map[string]string
+	}{
+		{
+			"shows",
+			func() (int, error) {
+				s, err := c.GetShows(QueryOpts{Paging: Paging{Limit: 10}, Filter: Filter{Resolution: ResolutionHD}})
+				return len(s), err
+			},
+			"/Users/user/Items",
+			map[string]string{"IncludeItemTypes": "Series", "Recursive": "true", "IsHd": "true", "Limit": "10"},
+		},
+		{
+			"seasons",
+			func() (int, error) { s, err := c.GetSeasons("series"); return len(s), err },
+			"/Shows/series/Seasons",
+			map[string]string{"UserId": "user"},
+		},
+		{
+			"episodes of season",
+			func() (int, error) {
+				e, err := c.GetEpisodes("series", "season", Paging{StartIndex: 3, Limit: 5})
+				return len(e), err
+			},
+			"/Shows/series/Episodes",
+			map[string]string{"SeasonId": "season", "StartIndex": "3", "Limit": "5"},
+		},
+		{
+			"next up",
+			func() (int, error) { e, err := c.GetNextUp("series", Paging{}); return len(e), err },
+			"/Shows/NextUp",
+			map[string]string{"SeriesId": "series"},
+		},
+		{
+			"resume episodes",
+			func() (int, error) { e, err := c.GetResumeEpisodes(Paging{Limit: 4}); return len(e), err },
+			"/Users/user/Items/Resume",
+			map[string]string{"IncludeItemTypes": "Episode", "Limit": "4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := tt.call()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != 1 {
+				t.Errorf("got %d items, want 1", n)
+			}
+			if path != tt.wantPath {
+				t.Errorf("got path %s, want %s", path, tt.wantPath)
+			}
+			for key, want := range tt.wantParams {
+				if got := query.Get(key); got != want {
+					t.Errorf("got %s=%q, want %q", key, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package jellyfin
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const randomOrderCacheSize = 8
+
+// randomOrderCache remembers the shuffled ID order of recent seeded random queries,
+// so that paging through them does not refetch all matching IDs for each page.
+type randomOrderCache struct {
+	mu     sync.Mutex
+	keys   []string
+	orders map[string][]string
+}
+
+func (r *randomOrderCache) get(key string) ([]string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ids, ok := r.orders[key]
+	return ids, ok
+}
+
+func (r *randomOrderCache) put(key string, ids []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.orders == nil {
+		r.orders = make(map[string][]string)
+	}
+	if _, ok := r.orders[key]; !ok {
+		r.keys = append(r.keys, key)
+	}
+	r.orders[key] = ids
+	if len(r.keys) > randomOrderCacheSize {
+		delete(r.orders, r.keys[0])
+		r.keys = r.keys[1:]
+	}
+}
+
+// seededRandomPage returns the IDs of the requested page of a deterministic
+// shuffle of all items matching the query, in order. The paging and sorting
+// parameters are removed from params, so that the page can then be fetched
+// with queryItemsByIDs.
+func (c *Client) seededRandomPage(path string, params params, seed int64, paging Paging) ([]string, error) {
+	for _, k := range []string{"StartIndex", "Limit", "SortBy", "SortOrder"} {
+		delete(params, k)
+	}
+
+	key := randomOrderKey(path, params, seed)
+	ids, ok := c.randomOrders.get(key)
+	if !ok {
+		var err error
+		if ids, err = c.getMatchingIDs(path, params); err != nil {
+			return nil, err
+		}
+		// sort first so the shuffle does not depend on the server's order
+		sort.Strings(ids)
+		rand.New(rand.NewSource(seed)).Shuffle(len(ids), func(i, j int) {
+			ids[i], ids[j] = ids[j], ids[i]
+		})
+		c.randomOrders.put(key, ids)
+	}
+
+	start := paging.StartIndex
+	if start > len(ids) {
+		start = len(ids)
+	}
+	end := len(ids)
+	if paging.Limit > 0 && start+paging.Limit < end {
+		end = start + paging.Limit
+	}
+	return ids[start:end], nil
+}
+
+// getMatchingIDs returns the IDs of all items matching the query, without other fields.
+func (c *Client) getMatchingIDs(path string, query params) ([]string, error) {
+	params := params{}
+	for k, v := range query {
+		params[k] = v
+	}
+	params.setResponse(ResponseOpts{Preset: PresetMinimal}, fieldSet{})
+	params["SortBy"] = "SortName"
+
+	resp, err := c.get(path, params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Close()
+
+	dto := struct {
+		Items []struct {
+			ID string `json:"Id"`
+		} `json:"Items"`
+	}{}
+	if err := json.NewDecoder(resp).Decode(&dto); err != nil {
+		return nil, fmt.Errorf("decode json: %v", err)
+	}
+	ids := make([]string, len(dto.Items))
+	for i, item := range dto.Items {
+		ids[i] = item.ID
+	}
+	return ids, nil
+}
+
+func randomOrderKey(path string, params params, seed int64) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		if k != "Fields" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s|%d", path, seed)
+	for _, k := range keys {
+		fmt.Fprintf(&sb, "|%s=%s", k, params[k])
+	}
+	return sb.String()
+}
@@ -0,0 +1,68 @@
+package jellyfin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestGetSongs_SeededRandom(t *testing.T) {
+	var all []string
+	for i := 0; i < 2*maxIDsPerRequest+50; i++ {
+		all = append(all, fmt.Sprintf("song%03d", i))
+	}
+	var mu sync.Mutex
+	var listings int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		ids := all
+		if q := r.URL.Query().Get("Ids"); q != "" {
+			ids = strings.Split(q, ",")
+			if len(ids) > maxIDsPerRequest {
+				t.Errorf("got %d IDs in one request, want at most %d", len(ids), maxIDsPerRequest)
+			}
+			if r.URL.Query().Get("Limit") != "" || r.URL.Query().Get("StartIndex") != "" {
+				t.Errorf("got paging in ID request %s", r.URL.RawQuery)
+			}
+		} else {
+			mu.Lock()
+			listings++
+			mu.Unlock()
+		}
+		var items []map[string]string
+		for _, id := range ids {
+			items = append(items, map[string]string{"Id": id})
+		}
+		json.NewEncoder(w).Encode(map[string]any{"Items": items})
+	})
+
+	songIDs := func(paging Paging) []string {
+		t.Helper()
+		songs, err := c.GetSongs(QueryOpts{Sort: Sort{Field: SortByRandom, Seed: 42}, Paging: paging})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids := make([]string, len(songs))
+		for i, s := range songs {
+			ids[i] = s.Id
+		}
+		return ids
+	}
+
+	shuffled := songIDs(Paging{})
+	if len(shuffled) != len(all) || reflect.DeepEqual(shuffled, all) {
+		t.Fatalf("got %d songs in order %v, want all %d shuffled", len(shuffled), shuffled, len(all))
+	}
+	if got := songIDs(Paging{StartIndex: 90, Limit: 20}); !reflect.DeepEqual(got, shuffled[90:110]) {
+		t.Errorf("got page %v, want %v", got, shuffled[90:110])
+	}
+	if got := songIDs(Paging{StartIndex: len(all)}); len(got) != 0 {
+		t.Errorf("got %d songs past the end, want none", len(got))
+	}
+	if listings != 1 {
+		t.Errorf("listed all songs %d times, want once", listings)
+	}
+}
@@ -0,0 +1,192 @@
+package jellyfin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultBatchConcurrency = 4
+
+type emptyBody struct{}
+
+// MarkPlayed marks the item as played by the logged-in user.
+// If at is the zero time, the server records the current time as the play date.
+func (c *Client) MarkPlayed(id string, at time.Time) error {
+	params := c.defaultParams()
+	if !at.IsZero() {
+		params["DatePlayed"] = formatJellyfinTime(at)
+	}
+	resp, err := c.post(fmt.Sprintf("/Users/%s/PlayedItems/%s", c.userID, id), params, emptyBody{})
+	if err != nil {
+		return fmt.Errorf("mark played: %w", err)
+	}
+	resp.Close()
+	return nil
+}
+
+// MarkUnplayed clears the played state of the item for the logged-in user.
+func (c *Client) MarkUnplayed(id string) error {
+	resp, err := c.delete(fmt.Sprintf("/Users/%s/PlayedItems/%s", c.userID, id), c.defaultParams())
+	if err != nil {
+		return fmt.Errorf("mark unplayed: %w", err)
+	}
+	resp.Close()
+	return nil
+}
+
+// SetLike sets a "like" rating on the item for the logged-in user.
+func (c *Client) SetLike(id string) error {
+	return c.setRating(id, true)
+}
+
+// SetDislike sets a "dislike" rating on the item for the logged-in user.
+func (c *Client) SetDislike(id string) error {
+	return c.setRating(id, false)
+}
+
+// ClearRating removes any like/dislike rating from the item for the logged-in user.
+func (c *Client) ClearRating(id string) error {
+	resp, err := c.delete(fmt.Sprintf("/Users/%s/Items/%s/Rating", c.userID, id), c.defaultParams())
+	if err != nil {
+		return fmt.Errorf("clear rating: %w", err)
+	}
+	resp.Close()
+	return nil
+}
+
+func (c *Client) setRating(id string, likes bool) error {
+	params := c.defaultParams()
+	params["Likes"] = fmt.Sprintf("%t", likes)
+	resp, err := c.post(fmt.Sprintf("/Users/%s/Items/%s/Rating", c.userID, id), params, emptyBody{})
+	if err != nil {
+		return fmt.Errorf("set rating: %w", err)
+	}
+	resp.Close()
+	return nil
+}
+
+// UserDataAction is a change to the logged-in user's data for an item.
+type UserDataAction int
+
+const (
+	ActionMarkPlayed UserDataAction = iota
+	ActionMarkUnplayed
+	ActionLike
+	ActionDislike
+	ActionClearRating
+	ActionFavorite
+	ActionUnfavorite
+)
+
+// BatchError is returned by batch operations when one or more items failed.
+// Items not present in Errors were updated successfully.
+type BatchError struct {
+	// Errors maps item IDs to the error encountered for that item.
+	Errors map[string]error
+}
+
+func (e *BatchError) Error() string {
+	ids := e.FailedIDs()
+	msgs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", id, e.Errors[id]))
+	}
+	return fmt.Sprintf("%d of the items failed: %s", len(ids), strings.Join(msgs, "; "))
+}
+
+// FailedIDs returns the IDs of the items that failed, in sorted order.
+func (e *BatchError) FailedIDs() []string {
+	ids := make([]string, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// BatchUpdateUserData applies the action to all of the given items,
+// running at most maxConcurrent requests at once (a default is used if <= 0).
+// If some items fail, a *BatchError is returned describing the failures.
+func (c *Client) BatchUpdateUserData(itemIDs []string, action UserDataAction, maxConcurrent int) error {
+	var apply func(string) error
+	switch action {
+	case ActionMarkPlayed:
+		apply = func(id string) error { return c.MarkPlayed(id, time.Time{}) }
+	case ActionMarkUnplayed:
+		apply = c.MarkUnplayed
+	case ActionLike:
+		apply = c.SetLike
+	case ActionDislike:
+		apply = c.SetDislike
+	case ActionClearRating:
+		apply = c.ClearRating
+	case ActionFavorite:
+		apply = func(id string) error { return c.SetFavorite(id, true) }
+	case ActionUnfavorite:
+		apply = func(id string) error { return c.SetFavorite(id, false) }
+	default:
+		return fmt.Errorf("unknown user data action: %d", action)
+	}
+	return runBatch(context.Background(), itemIDs, maxConcurrent, func(_ context.Context, id string) error {
+		return apply(id)
+	})
+}
+
+// runBatch calls fn for each id with bounded concurrency, collecting
+// per-id failures into a *BatchError. Once ctx is done, the ids not
+// yet started are not passed to fn and fail with the context's error.
+func runBatch(ctx context.Context, ids []string, maxConcurrent int, fn func(ctx context.Context, id string) error) error {
+	if maxConcurrent <= 0 {
+		maxConcurrent = defaultBatchConcurrency
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	errs := make(map[string]error)
+	fail := func(id string, err error) {
+		mu.Lock()
+		errs[id] = err
+		mu.Unlock()
+	}
+	sem := make(chan struct{}, maxConcurrent)
+	for _, id := range ids {
+		acquired := false
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+			acquired = true
+		}
+		if err := ctx.Err(); err != nil {
+			if acquired {
+				<-sem
+			}
+			fail(id, err)
+			continue
+		}
+		wg.Add(1)
+		go func(id string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := fn(ctx, id); err != nil {
+				fail(id, err)
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return &BatchError{Errors: errs}
+	}
+	return nil
+}
+
+// formatJellyfinTime formats t in the ISO 8601 form expected by the server.
+func formatJellyfinTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.0000000Z")
+}
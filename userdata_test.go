@@ -0,0 +1,99 @@
+package jellyfin
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUserDataEndpoints(t *testing.T) {
+	var got []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		req := r.Method + " " + r.URL.Path
+		if likes := r.URL.Query().Get("Likes"); likes != "" {
+			req += " Likes=" + likes
+		}
+		if date := r.URL.Query().Get("DatePlayed"); date != "" {
+			req += " DatePlayed=" + date
+		}
+		got = append(got, req)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	at := time.Date(2024, 5, 1, 12, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+	calls := []struct {
+		call func() error
+		want string
+	}{
+		{func() error { return c.MarkPlayed("a", at) }, "POST /Users/user/PlayedItems/a DatePlayed=2024-05-01T10:30:00.0000000Z"},
+		{func() error { return c.MarkPlayed("a", time.Time{}) }, "POST /Users/user/PlayedItems/a"},
+		{func() error { return c.MarkUnplayed("a") }, "DELETE /Users/user/PlayedItems/a"},
+		{func() error { return c.SetLike("a") }, "POST /Users/user/Items/a/Rating Likes=true"},
+		{func() error { return c.SetDislike("a") }, "POST /Users/user/Items/a/Rating Likes=false"},
+		{func() error { return c.ClearRating("a") }, "DELETE /Users/user/Items/a/Rating"},
+	}
+	for _, tt := range calls {
+		got = nil
+		if err := tt.call(); err != nil {
+			t.Errorf("%s: %v", tt.want, err)
+		}
+		if len(got) != 1 || got[0] != tt.want {
+			t.Errorf("got requests %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestBatchUpdateUserData(t *testing.T) {
+	var mu sync.Mutex
+	var liked []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		id := strings.Split(r.URL.Path, "/")[4]
+		if strings.HasPrefix(id, "bad") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		mu.Lock()
+		liked = append(liked, id)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := c.BatchUpdateUserData([]string{"a", "bad2", "b", "bad1", "c"}, ActionLike, 2)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("got error %v, want a *BatchError", err)
+	}
+	if got, want := batchErr.FailedIDs(), []string{"bad1", "bad2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got failed IDs %v, want %v", got, want)
+	}
+	if len(liked) != 3 {
+		t.Errorf("got %d liked items, want 3", len(liked))
+	}
+	if err := c.BatchUpdateUserData(nil, UserDataAction(-1), 0); err == nil {
+		t.Error("expected error for unknown action")
+	}
+}
+
+func TestBatchUpdateUserData_DeviceID(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	// the device ID is computed by the first requests, concurrently
+	c, err := NewClient(srv.URL, "test", "1.0", WithSession(Session{UserID: "user", Token: "token"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.BatchUpdateUserData([]string{"a", "b", "c", "d"}, ActionFavorite, 4); err != nil {
+		t.Fatal(err)
+	}
+	if c.Session().DeviceID == "" {
+		t.Error("device ID not set")
+	}
+}
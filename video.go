@@ -0,0 +1,195 @@
+package jellyfin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type MediaStreamType string
+
+const (
+	MediaStreamTypeAudio    MediaStreamType = "Audio"
+	MediaStreamTypeVideo    MediaStreamType = "Video"
+	MediaStreamTypeSubtitle MediaStreamType = "Subtitle"
+)
+
+type SubtitleFormat string
+
+const (
+	SubtitleFormatSRT SubtitleFormat = "srt"
+	SubtitleFormatVTT SubtitleFormat = "vtt"
+)
+
+type SubtitleMethod string
+
+const (
+	// Burn the subtitles into the video. Requires transcoding.
+	SubtitleMethodEncode SubtitleMethod = "Encode"
+	// Embed the subtitles in the output container.
+	SubtitleMethodEmbed SubtitleMethod = "Embed"
+	// Deliver the subtitles as a separate HLS stream.
+	SubtitleMethodHls SubtitleMethod = "Hls"
+)
+
+type VideoTranscodeOptions struct {
+	// Video codec to request, e.g. "h264"
+	VideoCodec string
+
+	// Audio codec to request, e.g. "aac"
+	AudioCodec string
+
+	// Requested container, e.g. "mp4" or "ts".
+	// Required when requesting transcoding of a progressive stream.
+	Container string
+
+	// Requested bit rates. If 0, use encoder default.
+	VideoBitRate uint32
+	AudioBitRate uint32
+
+	// Maximum output dimensions. If 0, the source dimensions are kept.
+	MaxWidth  int
+	MaxHeight int
+}
+
+type VideoStreamOptions struct {
+	// MediaSourceID of the version of the item to play.
+	// If empty, the default media source is used.
+	MediaSourceID string
+
+	// Index of the audio stream (MediaStream.Index) to play.
+	// If nil, the default audio stream is used.
+	AudioStreamIndex *int
+
+	// Index of the subtitle stream (MediaStream.Index) to show.
+	// If nil, no subtitles are delivered in the stream.
+	SubtitleStreamIndex *int
+
+	// How the selected subtitle stream is delivered.
+	SubtitleMethod SubtitleMethod
+
+	// Transcoding options. If nil, the file is streamed directly.
+	// HLS streams are always transcoded or remuxed.
+	Transcode *VideoTranscodeOptions
+}
+
+// StreamIndex returns a pointer to i, for use in VideoStreamOptions.
+func StreamIndex(i int) *int {
+	return &i
+}
+
+// GetVideoStreamURL returns a progressive stream URL for a video item.
+// A transcoded stream requires the Container transcoding option, since the
+// server selects the output container by the extension of the URL path.
+func (c *Client) GetVideoStreamURL(id string, opts *VideoStreamOptions) (string, error) {
+	params := c.videoStreamParams(id, opts)
+	if opts == nil || opts.Transcode == nil {
+		params["static"] = "true"
+		return c.encodeGETUrl(fmt.Sprintf("/Videos/%s/stream", id), params)
+	}
+	if opts.Transcode.Container == "" {
+		return "", errors.New("container is required to transcode a progressive stream")
+	}
+	return c.encodeGETUrl(fmt.Sprintf("/Videos/%s/stream.%s", id, opts.Transcode.Container), params)
+}
+
+// GetVideoHLSURL returns an HLS master playlist URL for a video item.
+func (c *Client) GetVideoHLSURL(id string, opts *VideoStreamOptions) (string, error) {
+	params := c.videoStreamParams(id, opts)
+	if _, ok := params["videoCodec"]; !ok {
+		params["videoCodec"] = "h264"
+	}
+	if _, ok := params["audioCodec"]; !ok {
+		params["audioCodec"] = "aac"
+	}
+	params["segmentContainer"] = "ts"
+	return c.encodeGETUrl(fmt.Sprintf("/Videos/%s/master.m3u8", id), params)
+}
+
+func (c *Client) videoStreamParams(id string, opts *VideoStreamOptions) params {
+	params := c.defaultParams()
+	params["playSessionId"] = randomKey(32)
+	params["api_key"] = c.token
+	params["mediaSourceId"] = id
+	if opts == nil {
+		return params
+	}
+
+	if opts.MediaSourceID != "" {
+		params["mediaSourceId"] = opts.MediaSourceID
+	}
+	if opts.AudioStreamIndex != nil {
+		params["audioStreamIndex"] = strconv.Itoa(*opts.AudioStreamIndex)
+	}
+	if opts.SubtitleStreamIndex != nil {
+		params["subtitleStreamIndex"] = strconv.Itoa(*opts.SubtitleStreamIndex)
+		if opts.SubtitleMethod != "" {
+			params["subtitleMethod"] = string(opts.SubtitleMethod)
+		}
+	}
+	if t := opts.Transcode; t != nil {
+		if t.Container != "" {
+			params["container"] = t.Container
+		}
+		if t.VideoCodec != "" {
+			params["videoCodec"] = t.VideoCodec
+		}
+		if t.AudioCodec != "" {
+			params["audioCodec"] = t.AudioCodec
+		}
+		if t.VideoBitRate > 0 {
+			params["videoBitRate"] = strconv.Itoa(int(t.VideoBitRate))
+		}
+		if t.AudioBitRate > 0 {
+			params["audioBitRate"] = strconv.Itoa(int(t.AudioBitRate))
+		}
+		if t.MaxWidth > 0 {
+			params["maxWidth"] = strconv.Itoa(t.MaxWidth)
+		}
+		if t.MaxHeight > 0 {
+			params["maxHeight"] = strconv.Itoa(t.MaxHeight)
+		}
+	}
+	return params
+}
+
+// GetSubtitles downloads a subtitle stream of a video in the given format.
+// The caller must close the returned reader.
+func (c *Client) GetSubtitles(itemID, mediaSourceID string, streamIndex int, format SubtitleFormat) (io.ReadCloser, error) {
+	if mediaSourceID == "" {
+		mediaSourceID = itemID
+	}
+	path := fmt.Sprintf("/Videos/%s/%s/Subtitles/%d/Stream.%s", itemID, mediaSourceID, streamIndex, format)
+	resp, err := c.get(path, c.defaultParams())
+	if err != nil {
+		return nil, fmt.Errorf("get subtitles: %v", err)
+	}
+	return resp, nil
+}
+
+// Streams returns the media streams of the given type.
+func (m *MediaSource) Streams(streamType MediaStreamType) []*MediaStream {
+	var streams []*MediaStream
+	for _, s := range m.MediaStreams {
+		if s.Type == string(streamType) {
+			streams = append(streams, s)
+		}
+	}
+	return streams
+}
+
+// DefaultStream returns the default media stream of the given type, or the first
+// stream of that type if none is marked default. Returns nil if there is none.
+func (m *MediaSource) DefaultStream(streamType MediaStreamType) *MediaStream {
+	streams := m.Streams(streamType)
+	for _, s := range streams {
+		if s.IsDefault {
+			return s
+		}
+	}
+	if len(streams) > 0 && streamType != MediaStreamTypeSubtitle {
+		return streams[0]
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package jellyfin
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestGetVideoStreamURL(t *testing.T) {
+	c, err := NewClient("https://jellyfin.example.com/jf", "test", "1.0", WithSession(Session{UserID: "user", Token: "token", DeviceID: "device"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		opts       *VideoStreamOptions
+		wantPath   string
+		wantParams map[string]string
+	}{
+		{
+			name:       "direct",
+			opts:       nil,
+			wantPath:   "/jf/Videos/movie/stream",
+			wantParams: map[string]string{"static": "true", "mediaSourceId": "movie", "api_key": "token"},
+		},
+		{
+			name: "direct with streams",
+			opts: &VideoStreamOptions{
+				MediaSourceID:       "source",
+				AudioStreamIndex:    StreamIndex(2),
+				SubtitleStreamIndex: StreamIndex(0),
+				SubtitleMethod:      SubtitleMethodEmbed,
+			},
+			wantPath: "/jf/Videos/movie/stream",
+			wantParams: map[string]string{
+				"static": "true", "mediaSourceId": "source", "audioStreamIndex": "2",
+				"subtitleStreamIndex": "0", "subtitleMethod": "Embed",
+			},
+		},
+		{
+			name: "transcoded",
+			opts: &VideoStreamOptions{Transcode: &VideoTranscodeOptions{
+				Container: "mp4", VideoCodec: "h264", AudioCodec: "aac", VideoBitRate: 4000000, MaxHeight: 720,
+			}},
+			wantPath: "/jf/Videos/movie/stream.mp4",
+			wantParams: map[string]string{
+				"static": "", "videoCodec": "h264", "audioCodec": "aac", "videoBitRate": "4000000", "maxHeight": "720",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := c.GetVideoStreamURL("movie", tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			u, err := url.Parse(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.Path != tt.wantPath {
+				t.Errorf("got path %s, want %s", u.Path, tt.wantPath)
+			}
+			q := u.Query()
+			for key, want := range tt.wantParams {
+				if got := q.Get(key); got != want {
+					t.Errorf("got %s=%q, want %q", key, got, want)
+				}
+			}
+			if q.Get("playSessionId") == "" {
+				t.Error("missing play session ID")
+			}
+		})
+	}
+
+	if _, err := c.GetVideoStreamURL("movie", &VideoStreamOptions{Transcode: &VideoTranscodeOptions{VideoCodec: "h264"}}); err == nil {
+		t.Error("expected error for transcoding without a container")
+	}
+}
+
+func TestGetVideoHLSURL(t *testing.T) {
+	c, err := NewClient("https://jellyfin.example.com", "test", "1.0", WithSession(Session{UserID: "user", Token: "token", DeviceID: "device"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := c.GetVideoHLSURL("movie", &VideoStreamOptions{Transcode: &VideoTranscodeOptions{VideoCodec: "hevc"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _ := url.Parse(s)
+	q := u.Query()
+	if u.Path != "/Videos/movie/master.m3u8" || q.Get("videoCodec") != "hevc" || q.Get("audioCodec") != "aac" || q.Get("segmentContainer") != "ts" {
+		t.Errorf("got URL %s", s)
+	}
+}
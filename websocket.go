@@ -0,0 +1,128 @@
+package jellyfin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/coder/websocket"
+)
+
+const (
+	socketReadLimit   = 16 << 20
+	socketKeepAlive   = 30 * time.Second
+	msgLibraryChanged = "LibraryChanged"
+	msgForceKeepAlive = "ForceKeepAlive"
+	msgKeepAlive      = "KeepAlive"
+)
+
+// LibraryChange is sent by the server when items are added to, updated in
+// or removed from the library. All fields are item IDs.
+type LibraryChange struct {
+	ItemsAdded         []string `json:"ItemsAdded"`
+	ItemsUpdated       []string `json:"ItemsUpdated"`
+	ItemsRemoved       []string `json:"ItemsRemoved"`
+	FoldersAddedTo     []string `json:"FoldersAddedTo"`
+	FoldersRemovedFrom []string `json:"FoldersRemovedFrom"`
+}
+
+type socketMessage struct {
+	MessageType string          `json:"MessageType"`
+	Data        json.RawMessage `json:"Data,omitempty"`
+}
+
+// WatchLibraryChanges connects to the server's WebSocket and calls fn for each
+// library change until ctx is done or the connection is lost. fn is called
+// from a single goroutine and should return quickly.
+// The returned error is ctx.Err() if ctx ended the watch.
+func (c *Client) WatchLibraryChanges(ctx context.Context, fn func(LibraryChange)) error {
+	conn, err := c.dialSocket(ctx)
+	if err != nil {
+		return fmt.Errorf("connect socket: %w", err)
+	}
+	defer conn.CloseNow()
+
+	done := make(chan struct{})
+	defer close(done)
+	keepAlive := make(chan time.Duration, 1)
+	go func() {
+		ticker := time.NewTicker(socketKeepAlive)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case d := <-keepAlive:
+				ticker.Reset(d)
+			case <-ticker.C:
+				msg, _ := json.Marshal(socketMessage{MessageType: msgKeepAlive})
+				conn.Write(ctx, websocket.MessageText, msg)
+			}
+		}
+	}()
+
+	for {
+		_, msg, err := conn.Read(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("read socket: %w", err)
+		}
+		var envelope socketMessage
+		if err := json.Unmarshal(msg, &envelope); err != nil {
+			continue
+		}
+		switch envelope.MessageType {
+		case msgLibraryChanged:
+			var change LibraryChange
+			if err := json.Unmarshal(envelope.Data, &change); err == nil {
+				fn(change)
+			}
+		case msgForceKeepAlive:
+			var seconds int
+			if err := json.Unmarshal(envelope.Data, &seconds); err == nil && seconds > 1 {
+				select {
+				case keepAlive <- time.Duration(seconds) * time.Second / 2:
+				default:
+				}
+			}
+		}
+	}
+}
+
+// dialSocket opens the server's WebSocket, authenticated as the logged-in user.
+// The handshake goes through the Client's HTTPClient, so its transport,
+// proxy and TLS settings apply.
+func (c *Client) dialSocket(ctx context.Context) (*websocket.Conn, error) {
+	u := c.BaseURL()
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/socket"
+	u.RawQuery = url.Values{"api_key": {c.token}, "deviceId": {c.ensureDeviceID()}}.Encode()
+
+	// the client timeout would apply to the whole connection,
+	// so it is only used to bound the handshake
+	httpClient := *c.HTTPClient
+	httpClient.Timeout = 0
+	dialCtx := ctx
+	if c.HTTPClient.Timeout > 0 {
+		var cancel context.CancelFunc
+		dialCtx, cancel = context.WithTimeout(ctx, c.HTTPClient.Timeout)
+		defer cancel()
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", c.authHeader()+fmt.Sprintf(", Token=\"%s\"", c.token))
+	conn, _, err := websocket.Dial(dialCtx, u.String(), &websocket.DialOptions{
+		HTTPClient: &httpClient,
+		HTTPHeader: header,
+	})
+	if err != nil {
+		return nil, err
+	}
+	conn.SetReadLimit(socketReadLimit)
+	return conn, nil
+}
@@ -0,0 +1,87 @@
+package jellyfin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+)
+
+func TestWatchLibraryChanges(t *testing.T) {
+	change := LibraryChange{ItemsRemoved: []string{"a", "b"}, ItemsAdded: []string{"c"}}
+	ws := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/socket" || r.URL.Query().Get("api_key") != "token" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.CloseNow()
+
+		ctx := r.Context()
+		conn.Write(ctx, websocket.MessageText, []byte(`{"MessageType":"ForceKeepAlive","Data":60}`))
+		data, _ := json.Marshal(change)
+		msg, _ := json.Marshal(socketMessage{MessageType: msgLibraryChanged, Data: data})
+		conn.Write(ctx, websocket.MessageText, msg)
+		// keep the connection open until the client hangs up
+		conn.Read(ctx)
+	}))
+	defer ws.Close()
+
+	client, err := NewClient(ws.URL, "test", "1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.token = "token"
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var got LibraryChange
+	err = client.WatchLibraryChanges(ctx, func(c LibraryChange) {
+		got = c
+		cancel()
+	})
+	if err != context.Canceled {
+		t.Errorf("got error %v, want context.Canceled", err)
+	}
+	if !reflect.DeepEqual(got, change) {
+		t.Errorf("got change %+v, want %+v", got, change)
+	}
+}
+
+func TestWatchLibraryChanges_Proxy(t *testing.T) {
+	// the handshake must go through the configured transport's proxy
+	proxied := make(chan string, 1)
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied <- r.URL.String()
+		http.Error(w, "proxy says no", http.StatusBadGateway)
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	httpClient := &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}}
+	client, err := NewClient("http://jellyfin.invalid", "test", "1.0", WithHTTPClient(httpClient))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.WatchLibraryChanges(context.Background(), func(LibraryChange) {}); err == nil {
+		t.Fatal("expected error from rejected handshake")
+	}
+	select {
+	case u := <-proxied:
+		if want := "http://jellyfin.invalid/socket"; len(u) < len(want) || u[:len(want)] != want {
+			t.Errorf("proxy got request for %s, want %s", u, want)
+		}
+	default:
+		t.Error("handshake did not go through the proxy")
+	}
+}